@@ -16,6 +16,7 @@ import (
 	"github.com/gardener/docforge/cmd/gendocs"
 	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/cmd/markdown"
+	"github.com/gardener/docforge/cmd/multiversion"
 	"github.com/gardener/docforge/cmd/persona"
 	"github.com/gardener/docforge/cmd/version"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
@@ -39,6 +40,7 @@ type options struct {
 	persona.Persona            `mapstructure:",squash"`
 	markdown.Markdown          `mapstructure:",squash"`
 	alias.Alias                `mapstructure:",squash"`
+	multiversion.MultiVersion  `mapstructure:",squash"`
 	repositoryhost.InitOptions `mapstructure:",squash"`
 }
 
@@ -7,25 +7,39 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/pkg/core"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/manifestplugins/alias"
 	"github.com/gardener/docforge/pkg/manifestplugins/docsy"
 	"github.com/gardener/docforge/pkg/manifestplugins/filetypefilter"
 	manifestmarkdown "github.com/gardener/docforge/pkg/manifestplugins/markdown"
+	"github.com/gardener/docforge/pkg/manifestplugins/multiversion"
 	"github.com/gardener/docforge/pkg/manifestplugins/persona"
 	"github.com/gardener/docforge/pkg/nodeplugins"
+	"github.com/gardener/docforge/pkg/nodeplugins/changelog"
 	"github.com/gardener/docforge/pkg/nodeplugins/downloader"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
 	personanodeplugin "github.com/gardener/docforge/pkg/nodeplugins/persona"
 	"github.com/gardener/docforge/pkg/osfakes/osshim"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/postprocessors/freshness"
+	"github.com/gardener/docforge/pkg/postprocessors/searchindex"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 )
 
 // TODO remove the ignore
@@ -44,6 +58,32 @@ func exec(ctx context.Context, vip *viper.Viper) error {
 	if existsPath {
 		return fmt.Errorf("hugo-structural-dirs contains a path instead a directory name")
 	}
+	if options.DryRun && !slices.Contains(manifest.ExportFormats(), options.DryRunFormat) {
+		return fmt.Errorf("dry-run-format %s is not one of %s", options.DryRunFormat, strings.Join(manifest.ExportFormats(), ", "))
+	}
+	if options.Hugo.Enabled && !slices.Contains(manifest.FrontmatterFormats(), options.Hugo.FrontmatterFormat) {
+		return fmt.Errorf("hugo-frontmatter-format %s is not one of %s", options.Hugo.FrontmatterFormat, strings.Join(manifest.FrontmatterFormats(), ", "))
+	}
+	if options.LinkStyle != "" && !slices.Contains(hugo.LinkStyles(), options.LinkStyle) {
+		return fmt.Errorf("hugo-link-style %s is not one of %s", options.LinkStyle, strings.Join(hugo.LinkStyles(), ", "))
+	}
+	if baseURL, err := url.Parse(options.BaseURL); options.LinkStyle == hugo.LinkStyleAbsolute && (err != nil || !baseURL.IsAbs()) {
+		return fmt.Errorf("hugo-link-style %s requires an absolute hugo-base-url", hugo.LinkStyleAbsolute)
+	}
+	for field := range options.GitInfoFrontmatterKeys {
+		if !slices.Contains(hugo.GitInfoFields(), field) {
+			return fmt.Errorf("hugo-git-info-frontmatter-keys field %s is not one of %s", field, strings.Join(hugo.GitInfoFields(), ", "))
+		}
+	}
+	if options.MaxSnippetIncludeDepth < 0 {
+		return fmt.Errorf("max-snippet-include-depth must not be negative")
+	}
+	if options.FreshnessReport != "" && (options.FreshnessStaleMonths <= 0 || options.FreshnessRepositoryLagMonths < 0) {
+		return fmt.Errorf("freshness-stale-months must be positive and freshness-repository-lag-months must not be negative")
+	}
+	if options.ChangelogDays <= 0 {
+		return fmt.Errorf("changelog-days must be positive")
+	}
 	localRH := []repositoryhost.Interface{}
 	for resource, mapped := range options.ResourceMappings {
 		localRH = append(localRH, repositoryhost.NewLocal(&osshim.OsShim{}, resource, mapped))
@@ -51,15 +91,69 @@ func exec(ctx context.Context, vip *viper.Viper) error {
 	if err != nil {
 		return err
 	}
+	commitFilter, err := repositoryhost.NewCommitFilter(options.CommitFilter)
+	if err != nil {
+		return fmt.Errorf("git-info-commit-filter is invalid: %w", err)
+	}
 	if rhs, err = initRepositoryHosts(ctx, options.InitOptions); err != nil {
 		return err
 	}
 
+	rhRegistry := registry.NewRegistryWithCommitFilter(commitFilter, append(localRH, rhs...)...)
+
+	if len(options.Versions) == 0 {
+		if err := build(ctx, options, rhs, rhRegistry, nil); err != nil {
+			return err
+		}
+		rhRegistry.LogRateLimits(ctx)
+		return nil
+	}
+	for _, version := range options.Versions {
+		versionOptions := options
+		versionOptions.DestinationPath = filepath.Join(options.DestinationPath, version)
+		if versionOptions.BaseURL, err = url.JoinPath(options.BaseURL, version); err != nil {
+			return err
+		}
+		if err := build(ctx, versionOptions, rhs, rhRegistry, &manifest.Version{Ref: version, Repositories: options.VersionedRepositories}); err != nil {
+			return fmt.Errorf("failed to build version %s: %w", version, err)
+		}
+	}
+	if !options.DryRun && !options.ValidateOnly {
+		versionsIndex, err := multiversion.VersionsIndex(options.Versions)
+		if err != nil {
+			return err
+		}
+		writer := &writers.FSWriter{Root: options.DestinationPath}
+		if err := writer.Write(multiversion.VersionsIndexFile, "", versionsIndex, nil, nil); err != nil {
+			return err
+		}
+	}
+	rhRegistry.LogRateLimits(ctx)
+	return nil
+}
+
+// manifestVars merges the vars from the config with the ones set from the command line
+func manifestVars(options Options) map[string]string {
+	vars := map[string]string{}
+	for k, v := range options.Vars {
+		vars[k] = v
+	}
+	for k, v := range options.Set {
+		vars[k] = v
+	}
+	return vars
+}
+
+// build resolves the manifest and constructs the documentation bundle. If version is not nil
+// the manifest resources are pinned to the version reference
+func build(ctx context.Context, options options, rhs []repositoryhost.Interface, rhRegistry registry.Interface, version *manifest.Version) error {
 	config := getReactorConfig(options.Options, options.Hugo, rhs)
+	// the post-processors are given the files written by the first stage
+	recorder := postprocessors.NewRecorder(config.Writer)
+	recorder.RecordText = options.SearchIndex != ""
+	config.Writer = recorder
 	manifestURL := options.ManifestPath
 
-	rhRegistry := registry.NewRegistry(append(localRH, config.RepositoryHosts...)...)
-
 	pluginTransformations := []manifest.NodeTransformation{}
 	if options.Persona.PersonaFilterEnabled {
 		personaPlugin := persona.Persona{}
@@ -83,21 +177,39 @@ func exec(ctx context.Context, vip *viper.Viper) error {
 		pluginTransformations = append(pluginTransformations, fileTypeFilterPlugin.PluginNodeTransformations()...)
 	}
 
-	documentNodes, err := manifest.ResolveManifest(manifestURL, rhRegistry, pluginTransformations...)
+	if version != nil {
+		multiVersionPlugin := multiversion.MultiVersion{Version: version.Ref}
+		pluginTransformations = append(pluginTransformations, multiVersionPlugin.PluginNodeTransformations()...)
+	}
+	resolveOptions := manifest.ResolveOptions{
+		Version:           version,
+		Vars:              manifestVars(options.Options),
+		Overlays:          options.Overlays,
+		MaxIncludeDepth:   options.MaxIncludeDepth,
+		FrontmatterSchema: options.FrontmatterSchema,
+	}
+	documentNodes, err := manifest.ResolveManifestWithOptions(manifestURL, resolveOptions, rhRegistry, pluginTransformations...)
 	if err != nil {
 		return fmt.Errorf("failed to resolve manifest %s. %+v", config.ManifestPath, err)
 	}
-	if config.DryRun {
-		fmt.Println(documentNodes[0])
-	}
-
 	additionalNodePlugins := []nodeplugins.Interface{}
 	if options.Persona.PersonaFilterEnabled {
 		additionalNodePlugins = append(additionalNodePlugins, &personanodeplugin.Plugin{Root: documentNodes[0], Writer: config.Writer})
 	}
+	resolver := linkresolver.New(documentNodes, rhRegistry, config.Hugo)
+	changelogPlugin := &changelog.Plugin{Resolver: resolver, Writer: config.Writer, Hugo: config.Hugo, Days: options.ChangelogDays}
+	additionalNodePlugins = append(additionalNodePlugins, changelogPlugin)
+	// the freshness report and the changelog pages are generated from the git info of the documents
+	var gitInfoCollector *githubinfo.Collector
+	if (options.FreshnessReport != "" || changelog.HasChangelogs(documentNodes)) && !config.DryRun && !config.ValidateOnly {
+		gitInfoCollector = githubinfo.NewCollector(config.GitInfoWriter)
+		config.GitInfoWriter = gitInfoCollector
+	}
+	// the code owners are only resolved for the owners frontmatter and the freshness report
+	gitInfoOwners := (config.Hugo.Enabled && config.Hugo.OwnersFrontmatter) || options.FreshnessReport != ""
 	// Stage 1
 	reactorWGStage1 := &sync.WaitGroup{}
-	mdPlugin, mdTasks, err := markdown.NewPlugin(config.DocumentWorkersCount, config.FailFast, reactorWGStage1, documentNodes, rhRegistry, config.Hugo, config.Writer, config.SkipLinkValidation, config.ValidationWorkersCount, config.HostsToReport, config.ResourceDownloadWorkersCount, config.GitInfoWriter)
+	mdPlugin, mdTasks, err := markdown.NewPlugin(config.DocumentWorkersCount, config.FailFast, reactorWGStage1, documentNodes, rhRegistry, config.Hugo, config.Writer, recorder, config.SkipLinkValidation, config.ValidationWorkersCount, config.HostsToReport, config.ResourceDownloadWorkersCount, config.GitInfoWriter, gitInfoOwners, config.MaxSnippetIncludeDepth)
 	if err != nil {
 		return err
 	}
@@ -108,8 +220,46 @@ func exec(ctx context.Context, vip *viper.Viper) error {
 	if err := core.Run(ctx, documentNodes, reactorWGStage1, append([]nodeplugins.Interface{mdPlugin, dPlugin}, additionalNodePlugins...), append(mdTasks, downloadTasks)); err != nil {
 		return err
 	}
-	// Stage 2 ...
+	// Stage 2
+	bundle := &postprocessors.Bundle{
+		Root:     documentNodes[0],
+		Nodes:    documentNodes,
+		Outputs:  recorder.Outputs(),
+		GitInfos: map[*manifest.Node]*repositoryhost.GitInfo{},
+		Time:     time.Now(),
+	}
+	if gitInfoCollector != nil {
+		bundle.GitInfos = gitInfoCollector.GitInfos()
+	}
+	postProcessors := []postprocessors.Interface{changelogPlugin}
+	if options.FreshnessReport != "" && gitInfoCollector == nil {
+		klog.Warningf("skipping the freshness report %s, the git info of the documents is not read in dry-run and validate-only mode\n", options.FreshnessReport)
+	} else if options.FreshnessReport != "" {
+		postProcessors = append(postProcessors, &freshness.PostProcessor{
+			Registry:            rhRegistry,
+			Writer:              config.Writer,
+			Path:                options.FreshnessReport,
+			StaleMonths:         options.FreshnessStaleMonths,
+			RepositoryLagMonths: options.FreshnessRepositoryLagMonths,
+			FrontmatterFormat:   config.Hugo.FrontmatterFormat,
+		})
+	}
+	if options.SearchIndex != "" {
+		postProcessors = append(postProcessors, &searchindex.PostProcessor{
+			Resolver:       resolver,
+			Writer:         config.Writer,
+			Path:           options.SearchIndex,
+			IndexFileNames: config.Hugo.IndexFileNames,
+		})
+	}
+	if err := postprocessors.Run(ctx, bundle, postProcessors...); err != nil {
+		return err
+	}
 
-	rhRegistry.LogRateLimits(ctx)
+	if config.DryRun {
+		if err := manifest.ExportTree(os.Stdout, documentNodes[0], config.DryRunFormat, config.Hugo.IndexFileNames); err != nil {
+			return fmt.Errorf("failed to print the projected hierarchy: %w", err)
+		}
+	}
 	return nil
 }
@@ -5,8 +5,13 @@
 package app
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -29,14 +34,50 @@ func configureFlags(command *cobra.Command, vip *viper.Viper) {
 		"If specified, docforge will download also additional github info for the files from the documentation structure into this destination.")
 	_ = vip.BindPFlag("github-info-destination", command.Flags().Lookup("github-info-destination"))
 
+	command.Flags().Bool("github-info-graphql", false,
+		"Reads the git info through the GitHub GraphQL API, which queries the full history of many files per request.")
+	_ = vip.BindPFlag("github-info-graphql", command.Flags().Lookup("github-info-graphql"))
+
+	command.Flags().Bool("github-info-cache", false,
+		"Caches the git info of files in the cache directory across runs, so that unchanged files are not queried again.")
+	_ = vip.BindPFlag("github-info-cache", command.Flags().Lookup("github-info-cache"))
+
 	command.Flags().Bool("fail-fast", false,
 		"Fail-fast vs fault tolerant operation.")
 	_ = vip.BindPFlag("fail-fast", command.Flags().Lookup("fail-fast"))
 
+	command.Flags().Int("changelog-days", 30,
+		"Number of days whose added and modified documents are listed by the pages of the changelog processor.")
+	_ = vip.BindPFlag("changelog-days", command.Flags().Lookup("changelog-days"))
+
+	command.Flags().String("freshness-report", "",
+		"If specified, docforge writes a report of the stale pages, the pages behind their source repository and the pages with a single contributor to this path of the bundle, as JSON and markdown page.")
+	_ = vip.BindPFlag("freshness-report", command.Flags().Lookup("freshness-report"))
+
+	command.Flags().Int("freshness-stale-months", 12,
+		"Number of months after which a page that is not modified is reported as stale.")
+	_ = vip.BindPFlag("freshness-stale-months", command.Flags().Lookup("freshness-stale-months"))
+
+	command.Flags().Int("freshness-repository-lag-months", 6,
+		"Number of months a page can lag behind the latest commit in its source repository before it is reported.")
+	_ = vip.BindPFlag("freshness-repository-lag-months", command.Flags().Lookup("freshness-repository-lag-months"))
+
+	command.Flags().String("search-index", "",
+		"If specified, docforge writes a search index with the title, URL, section, persona, tags, headings and text of the markdown documents to this path of the bundle, as JSON array.")
+	_ = vip.BindPFlag("search-index", command.Flags().Lookup("search-index"))
+
 	command.Flags().Bool("dry-run", false,
 		"Runs the command end-to-end but instead of writing files, it will output the projected file/folder hierarchy to the standard output and statistics for the processing of each file.")
 	_ = vip.BindPFlag("dry-run", command.Flags().Lookup("dry-run"))
 
+	command.Flags().String("dry-run-format", manifest.ExportFormatYAML,
+		fmt.Sprintf("Format of the projected file/folder hierarchy printed with --dry-run. One of %s.", strings.Join(manifest.ExportFormats(), ", ")))
+	_ = vip.BindPFlag("dry-run-format", command.Flags().Lookup("dry-run-format"))
+
+	command.Flags().Bool("validate-only", false,
+		"Processes the documents to validate their links and frontmatter without writing files.")
+	_ = vip.BindPFlag("validate-only", command.Flags().Lookup("validate-only"))
+
 	command.Flags().Int("document-workers", 25,
 		"Number of parallel workers for document processing.")
 	_ = vip.BindPFlag("document-workers", command.Flags().Lookup("document-workers"))
@@ -73,6 +114,46 @@ func configureFlags(command *cobra.Command, vip *viper.Viper) {
 		"When building a Hugo-compliant documentation bundle, files with filename matching one form this list (in that order) will be renamed to _index.md. Only useful with --hugo=true")
 	_ = vip.BindPFlag("hugo-section-files", command.Flags().Lookup("hugo-section-files"))
 
+	command.Flags().Bool("hugo-alerts", false,
+		"Converts GitHub alerts (> [!NOTE]) to the hugo-alert-templates from the config file, Docsy alert shortcodes by default. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-alerts", command.Flags().Lookup("hugo-alerts"))
+
+	command.Flags().Bool("hugo-title-from-heading", false,
+		"Uses the first level 1 heading of a document as its title and removes the heading from the document. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-title-from-heading", command.Flags().Lookup("hugo-title-from-heading"))
+
+	command.Flags().Bool("hugo-description-from-content", false,
+		"Uses the text of the first paragraph of a document as its description. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-description-from-content", command.Flags().Lookup("hugo-description-from-content"))
+
+	command.Flags().Int("hugo-description-length", 160,
+		"Maximum length of the descriptions taken from the document content. 0 means unlimited.")
+	_ = vip.BindPFlag("hugo-description-length", command.Flags().Lookup("hugo-description-length"))
+
+	command.Flags().String("hugo-frontmatter-format", "yaml",
+		"Format of the frontmatter written in documents, one of yaml, toml or json. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-frontmatter-format", command.Flags().Lookup("hugo-frontmatter-format"))
+
+	command.Flags().Bool("hugo-relref", false,
+		"Writes links between documents of the bundle as Hugo relref shortcodes, so that Hugo computes their URLs and fails on broken ones. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-relref", command.Flags().Lookup("hugo-relref"))
+
+	command.Flags().String("hugo-link-style", hugo.LinkStyleRootRelative,
+		fmt.Sprintf("Style of the links between resources of the bundle. One of %s.", strings.Join(hugo.LinkStyles(), ", ")))
+	_ = vip.BindPFlag("hugo-link-style", command.Flags().Lookup("hugo-link-style"))
+
+	command.Flags().Bool("hugo-disable-path-to-lower", false,
+		"Keeps the case of the resolved link paths, like the Hugo disablePathToLower config.")
+	_ = vip.BindPFlag("hugo-disable-path-to-lower", command.Flags().Lookup("hugo-disable-path-to-lower"))
+
+	command.Flags().Bool("hugo-git-info-frontmatter", false,
+		"Injects the git info of the documents into their frontmatter, so that Hugo reads lastmod, publishDate, author and contributors from it. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-git-info-frontmatter", command.Flags().Lookup("hugo-git-info-frontmatter"))
+
+	command.Flags().Bool("hugo-owners-frontmatter", false,
+		"Sets the owners of the documents from the CODEOWNERS files of their repositories as owners in their frontmatter. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-owners-frontmatter", command.Flags().Lookup("hugo-owners-frontmatter"))
+
 	command.Flags().StringSlice("content-files-formats", []string{},
 		"Supported content format extensions (example: .md)")
 	_ = vip.BindPFlag("content-files-formats", command.Flags().Lookup("content-files-formats"))
@@ -93,6 +174,30 @@ func configureFlags(command *cobra.Command, vip *viper.Viper) {
 		"When a link has a host from the given array it will get reported")
 	_ = vip.BindPFlag("hosts-to-report", command.Flags().Lookup("hosts-to-report"))
 
+	command.Flags().StringSlice("versions", []string{},
+		"List of references (branches, tags or commits) to build the documentation bundle for. Each version is built under destination/<version>.")
+	_ = vip.BindPFlag("versions", command.Flags().Lookup("versions"))
+
+	command.Flags().StringSlice("versioned-repositories", []string{},
+		"Repositories whose resource references are replaced with each of the --versions. Defaults to the repository of the manifest.")
+	_ = vip.BindPFlag("versioned-repositories", command.Flags().Lookup("versioned-repositories"))
+
+	command.Flags().StringToString("set", map[string]string{},
+		"Manifest variables in the form key=value, used for ${key} interpolation. Overrides the vars from the config file.")
+	_ = vip.BindPFlag("set", command.Flags().Lookup("set"))
+
+	command.Flags().StringSlice("overlays", []string{},
+		"Overlays patching the node tree of the manifest. Applied in the given order.")
+	_ = vip.BindPFlag("overlays", command.Flags().Lookup("overlays"))
+
+	command.Flags().Int("max-include-depth", 0,
+		"Maximum depth of nested manifests. 0 means unlimited.")
+	_ = vip.BindPFlag("max-include-depth", command.Flags().Lookup("max-include-depth"))
+
+	command.Flags().Int("max-snippet-include-depth", 5,
+		"Maximum depth of nested docforge:include directives in documents. 0 means unlimited.")
+	_ = vip.BindPFlag("max-snippet-include-depth", command.Flags().Lookup("max-snippet-include-depth"))
+
 	cacheDir := ""
 	userHomeDir, err := os.UserHomeDir()
 	if err == nil {
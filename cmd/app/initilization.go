@@ -47,7 +47,7 @@ func initRepositoryHosts(ctx context.Context, o repositoryhost.InitOptions) ([]r
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
-		rh := newRepositoryHost(u.Host, client, httpClient)
+		rh := newRepositoryHost(u.Host, client, httpClient, o)
 		rhs = append(rhs, rh)
 	}
 	if len(rhs) == 0 {
@@ -92,12 +92,20 @@ func buildClient(ctx context.Context, accessToken string, host string, cachePath
 	return client, httpClient, err
 }
 
-func newRepositoryHost(host string, client *github.Client, httpClient *http.Client) repositoryhost.Interface {
+func newRepositoryHost(host string, client *github.Client, httpClient *http.Client, o repositoryhost.InitOptions) repositoryhost.Interface {
 	rawHost := "raw." + host
 	if host == "github.com" {
 		rawHost = "raw.githubusercontent.com"
 	}
-	return repositoryhost.NewGHC(host, client, client.Repositories, client.Git, httpClient, []string{host, rawHost})
+	var repositories repositoryhost.Repositories = client.Repositories
+	if o.GitInfoGraphQL {
+		repositories = repositoryhost.NewGraphQLRepositories(client.Repositories, httpClient, repositoryhost.GraphQLEndpoint(host))
+	}
+	gitInfoCacheDir := ""
+	if o.GitInfoCache {
+		gitInfoCacheDir = filepath.Join(o.CacheHomeDir, "git-info", host)
+	}
+	return repositoryhost.NewGHC(host, client, repositories, client.Git, httpClient, []string{host, rawHost}, gitInfoCacheDir)
 }
 
 // NewReactor creates a Reactor from Options
@@ -108,9 +116,18 @@ func getReactorConfig(options Options, hugo hugo.Hugo, rhs []repositoryhost.Inte
 		Hugo:            hugo,
 	}
 
+	if config.DryRun || config.ValidateOnly {
+		config.Writer = &writers.DryRunWriter{}
+		if len(config.GhInfoDestination) > 0 {
+			config.GitInfoWriter = &writers.DryRunWriter{}
+		}
+		return config
+	}
+
 	config.Writer = &writers.FSWriter{
-		Root: config.DestinationPath,
-		Hugo: config.Hugo.Enabled,
+		Root:              config.DestinationPath,
+		Hugo:              config.Hugo.Enabled,
+		FrontmatterFormat: config.Hugo.FrontmatterFormat,
 	}
 
 	if len(config.GhInfoDestination) > 0 {
@@ -6,6 +6,7 @@ package app
 
 import (
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
 	"github.com/gardener/docforge/pkg/writers"
 )
@@ -13,17 +14,30 @@ import (
 // Options encapsulates the parameters for creating
 // new Reactor objects
 type Options struct {
-	DocumentWorkersCount         int      `mapstructure:"document-workers"`
-	ValidationWorkersCount       int      `mapstructure:"validation-workers"`
-	FailFast                     bool     `mapstructure:"fail-fast"`
-	DestinationPath              string   `mapstructure:"destination"`
-	ManifestPath                 string   `mapstructure:"manifest"`
-	ResourceDownloadWorkersCount int      `mapstructure:"download-workers"`
-	GhInfoDestination            string   `mapstructure:"github-info-destination"`
-	DryRun                       bool     `mapstructure:"dry-run"`
-	ContentFileFormats           []string `mapstructure:"content-files-formats"`
-	HostsToReport                []string `mapstructure:"hosts-to-report"`
-	SkipLinkValidation           bool     `mapstructure:"skip-link-validation"`
+	DocumentWorkersCount         int                         `mapstructure:"document-workers"`
+	ValidationWorkersCount       int                         `mapstructure:"validation-workers"`
+	FailFast                     bool                        `mapstructure:"fail-fast"`
+	DestinationPath              string                      `mapstructure:"destination"`
+	ManifestPath                 string                      `mapstructure:"manifest"`
+	ResourceDownloadWorkersCount int                         `mapstructure:"download-workers"`
+	GhInfoDestination            string                      `mapstructure:"github-info-destination"`
+	DryRun                       bool                        `mapstructure:"dry-run"`
+	DryRunFormat                 string                      `mapstructure:"dry-run-format"`
+	ContentFileFormats           []string                    `mapstructure:"content-files-formats"`
+	HostsToReport                []string                    `mapstructure:"hosts-to-report"`
+	SkipLinkValidation           bool                        `mapstructure:"skip-link-validation"`
+	Vars                         map[string]string           `mapstructure:"vars"`
+	Set                          map[string]string           `mapstructure:"set"`
+	Overlays                     []string                    `mapstructure:"overlays"`
+	MaxIncludeDepth              int                         `mapstructure:"max-include-depth"`
+	MaxSnippetIncludeDepth       int                         `mapstructure:"max-snippet-include-depth"`
+	FrontmatterSchema            *manifest.FrontmatterSchema `mapstructure:"frontmatter-schema"`
+	ValidateOnly                 bool                        `mapstructure:"validate-only"`
+	FreshnessReport              string                      `mapstructure:"freshness-report"`
+	FreshnessStaleMonths         int                         `mapstructure:"freshness-stale-months"`
+	FreshnessRepositoryLagMonths int                         `mapstructure:"freshness-repository-lag-months"`
+	ChangelogDays                int                         `mapstructure:"changelog-days"`
+	SearchIndex                  string                      `mapstructure:"search-index"`
 }
 
 // Writers struct that collects all the writesr
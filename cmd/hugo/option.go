@@ -6,9 +6,85 @@ package hugo
 
 // Hugo is the configuration options for creating HUGO implementations
 type Hugo struct {
-	Enabled            bool     `mapstructure:"hugo"`
-	PrettyURLs         bool     `mapstructure:"hugo-pretty-urls"`
-	BaseURL            string   `mapstructure:"hugo-base-url"`
-	IndexFileNames     []string `mapstructure:"hugo-section-files"`
-	HugoStructuralDirs []string `mapstructure:"hugo-structural-dirs"`
+	Enabled                bool                     `mapstructure:"hugo"`
+	PrettyURLs             bool                     `mapstructure:"hugo-pretty-urls"`
+	BaseURL                string                   `mapstructure:"hugo-base-url"`
+	IndexFileNames         []string                 `mapstructure:"hugo-section-files"`
+	HugoStructuralDirs     []string                 `mapstructure:"hugo-structural-dirs"`
+	AlertsEnabled          bool                     `mapstructure:"hugo-alerts"`
+	AlertTemplates         map[string]AlertTemplate `mapstructure:"hugo-alert-templates"`
+	TitleFromHeading       bool                     `mapstructure:"hugo-title-from-heading"`
+	DescriptionFromContent bool                     `mapstructure:"hugo-description-from-content"`
+	DescriptionLength      int                      `mapstructure:"hugo-description-length"`
+	FrontmatterFormat      string                   `mapstructure:"hugo-frontmatter-format"`
+	RelrefLinks            bool                     `mapstructure:"hugo-relref"`
+	LinkStyle              string                   `mapstructure:"hugo-link-style"`
+	Permalinks             map[string]string        `mapstructure:"hugo-permalinks"`
+	DisablePathToLower     bool                     `mapstructure:"hugo-disable-path-to-lower"`
+	GitInfoFrontmatter     bool                     `mapstructure:"hugo-git-info-frontmatter"`
+	GitInfoFrontmatterKeys map[string]string        `mapstructure:"hugo-git-info-frontmatter-keys"`
+	OwnersFrontmatter      bool                     `mapstructure:"hugo-owners-frontmatter"`
+}
+
+const (
+	// LinkStyleRelative writes links relative to the linking page
+	LinkStyleRelative = "relative"
+	// LinkStyleRootRelative writes links starting with / and the base URL path
+	LinkStyleRootRelative = "root-relative"
+	// LinkStyleAbsolute writes links starting with the base URL, which must be an absolute URL
+	LinkStyleAbsolute = "absolute"
+)
+
+// LinkStyles lists the supported styles of links between bundle resources
+func LinkStyles() []string {
+	return []string{LinkStyleRelative, LinkStyleRootRelative, LinkStyleAbsolute}
+}
+
+const (
+	// GitInfoLastmod is the date of the latest commit of a document
+	GitInfoLastmod = "lastmod"
+	// GitInfoPublishDate is the date of the first commit of a document
+	GitInfoPublishDate = "publishdate"
+	// GitInfoAuthor is the author of the first commit of a document
+	GitInfoAuthor = "author"
+	// GitInfoContributors are the authors of the other commits of a document
+	GitInfoContributors = "contributors"
+)
+
+// GitInfoFields lists the git info fields that can be injected into the document frontmatter
+func GitInfoFields() []string {
+	return []string{GitInfoLastmod, GitInfoPublishDate, GitInfoAuthor, GitInfoContributors}
+}
+
+// DefaultGitInfoFrontmatterKeys returns the frontmatter keys of the git info fields that Hugo reads
+func DefaultGitInfoFrontmatterKeys() map[string]string {
+	return map[string]string{
+		GitInfoLastmod:      "lastmod",
+		GitInfoPublishDate:  "publishDate",
+		GitInfoAuthor:       "author",
+		GitInfoContributors: "contributors",
+	}
+}
+
+// AlertTemplate is the markup a GitHub alert of a given type is converted to
+type AlertTemplate struct {
+	Open  string `mapstructure:"open"`
+	Close string `mapstructure:"close"`
+}
+
+// DefaultAlertTemplates returns the Docsy alert shortcodes for the GitHub alert types
+func DefaultAlertTemplates() map[string]AlertTemplate {
+	docsyAlert := func(title string, color string) AlertTemplate {
+		return AlertTemplate{
+			Open:  `{{% alert title="` + title + `" color="` + color + `" %}}`,
+			Close: `{{% /alert %}}`,
+		}
+	}
+	return map[string]AlertTemplate{
+		"note":      docsyAlert("Note", "info"),
+		"tip":       docsyAlert("Tip", "success"),
+		"important": docsyAlert("Important", "primary"),
+		"warning":   docsyAlert("Warning", "warning"),
+		"caution":   docsyAlert("Caution", "danger"),
+	}
 }
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package multiversion
+
+// MultiVersion is the configuration for building the documentation bundle for several versions
+type MultiVersion struct {
+	Versions              []string `mapstructure:"versions"`
+	VersionedRepositories []string `mapstructure:"versioned-repositories"`
+}
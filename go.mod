@@ -20,6 +20,7 @@ require (
 require (
 	github.com/google/go-github/v43 v43.0.0
 	github.com/gregjones/httpcache v0.0.0-20190611155906-901d90724c79
+	github.com/pelletier/go-toml/v2 v2.2.3
 	github.com/peterbourgon/diskv v2.0.1+incompatible
 	github.com/spf13/viper v1.20.0
 	github.com/yuin/goldmark v1.4.13
@@ -40,7 +41,6 @@ require (
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/nxadm/tail v1.4.8 // indirect
-	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
 	github.com/russross/blackfriday/v2 v2.0.1 // indirect
 	github.com/sagikazarmark/locafero v0.7.0 // indirect
 	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/internal/link"
+	"github.com/gardener/docforge/pkg/internal/must"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExportFormatYAML exports the node tree as YAML
+	ExportFormatYAML = "yaml"
+	// ExportFormatJSON exports the node tree as JSON
+	ExportFormatJSON = "json"
+	// ExportFormatDOT exports the node tree as Graphviz DOT graph
+	ExportFormatDOT = "dot"
+)
+
+// ExportFormats lists the supported node tree export formats
+func ExportFormats() []string {
+	return []string{ExportFormatYAML, ExportFormatJSON, ExportFormatDOT}
+}
+
+// ExportedNode is the projection of a resolved node that is exported
+type ExportedNode struct {
+	Name        string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Type        string                 `json:"type" yaml:"type"`
+	Destination string                 `json:"destination,omitempty" yaml:"destination,omitempty"`
+	Processor   string                 `json:"processor,omitempty" yaml:"processor,omitempty"`
+	Sources     []string               `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Frontmatter map[string]interface{} `json:"frontmatter,omitempty" yaml:"frontmatter,omitempty"`
+	Structure   []*ExportedNode        `json:"structure,omitempty" yaml:"structure,omitempty"`
+}
+
+// Export projects the node tree with root node into ExportedNode tree.
+// Files with names from indexFileNames are exported with the _index.md destination
+// the same way the writers persist them.
+func Export(node *Node, indexFileNames []string) *ExportedNode {
+	out := &ExportedNode{
+		Name:      node.Name(),
+		Type:      node.Type,
+		Processor: node.Processor,
+	}
+	if len(node.Frontmatter) > 0 {
+		out.Frontmatter = NormalizeFrontmatter(node.Frontmatter).(map[string]interface{})
+	}
+	if node.Path != "" {
+		out.Destination = node.NodePath()
+	}
+	if node.Type == "file" && slices.Contains(indexFileNames, node.Name()) {
+		out.Destination = must.Succeed(link.Build(node.Path, sectionFile))
+	}
+	if node.Source != "" {
+		out.Sources = append(out.Sources, node.Source)
+	}
+	out.Sources = append(out.Sources, node.MultiSource...)
+	for _, child := range node.Structure {
+		out.Structure = append(out.Structure, Export(child, indexFileNames))
+	}
+	return out
+}
+
+// ExportTree writes the node tree with root node in the given format
+func ExportTree(w io.Writer, node *Node, format string, indexFileNames []string) error {
+	exported := Export(node, indexFileNames)
+	switch format {
+	case ExportFormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(exported); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exported)
+	case ExportFormatDOT:
+		return writeDOT(w, exported)
+	default:
+		return fmt.Errorf("unsupported export format %s, expected one of %s", format, strings.Join(ExportFormats(), ","))
+	}
+}
+
+func writeDOT(w io.Writer, root *ExportedNode) error {
+	b := &strings.Builder{}
+	b.WriteString("digraph docforge {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	id := 0
+	var walk func(node *ExportedNode) string
+	walk = func(node *ExportedNode) string {
+		nodeID := fmt.Sprintf("n%d", id)
+		id++
+		fmt.Fprintf(b, "  %s [label=\"%s\"%s];\n", nodeID, dotEscape(dotLabel(node)), dotShape(node))
+		for _, child := range node.Structure {
+			childID := walk(child)
+			fmt.Fprintf(b, "  %s -> %s;\n", nodeID, childID)
+		}
+		return nodeID
+	}
+	walk(root)
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotLabel(node *ExportedNode) string {
+	switch node.Type {
+	case "file":
+		label := node.Destination
+		if node.Processor != "" {
+			label += "\n(" + node.Processor + ")"
+		}
+		for _, source := range node.Sources {
+			label += "\n" + source
+		}
+		return label
+	case "dir":
+		return node.Destination + "/"
+	default:
+		return "."
+	}
+}
+
+func dotShape(node *ExportedNode) string {
+	if node.Type == "dir" || node.Type == "manifest" {
+		return ", shape=folder"
+	}
+	return ""
+}
+
+func dotEscape(label string) string {
+	label = strings.ReplaceAll(label, `\`, `\\`)
+	label = strings.ReplaceAll(label, `"`, `\"`)
+	return strings.ReplaceAll(label, "\n", `\n`)
+}
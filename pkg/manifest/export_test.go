@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+var _ = Describe("Export", func() {
+	var (
+		root *manifest.Node
+		buf  *bytes.Buffer
+	)
+	BeforeEach(func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		allNodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/manifests/index_md_with_properties.yaml", r)
+		Expect(err).ToNot(HaveOccurred())
+		root = allNodes[0]
+		buf = &bytes.Buffer{}
+	})
+
+	It("projects the node tree", func() {
+		exported := manifest.Export(root, []string{"README.txt"})
+		Expect(exported.Type).To(Equal("manifest"))
+		Expect(exported.Structure).To(HaveLen(1))
+		foo := exported.Structure[0]
+		Expect(foo.Type).To(Equal("dir"))
+		Expect(foo.Destination).To(Equal("foo"))
+		bar := foo.Structure[0]
+		Expect(bar.Destination).To(Equal("foo/bar"))
+		var renamed *manifest.ExportedNode
+		for _, child := range bar.Structure {
+			if child.Name == "renamed.md" {
+				renamed = child
+			}
+		}
+		Expect(renamed).NotTo(BeNil())
+		Expect(renamed.Destination).To(Equal("foo/bar/renamed.md"))
+		Expect(renamed.Processor).To(Equal("downloader"))
+		Expect(renamed.Sources).To(Equal([]string{"https://github.com/gardener/docforge/blob/master/contents/website/blog/2024/README.txt"}))
+		one := bar.Structure[0]
+		Expect(one.Structure[0].Destination).To(Equal("foo/bar/one/_index.md"))
+	})
+
+	It("exports YAML", func() {
+		Expect(manifest.ExportTree(buf, root, manifest.ExportFormatYAML, nil)).To(Succeed())
+		exported := &manifest.ExportedNode{}
+		Expect(yaml.Unmarshal(buf.Bytes(), exported)).To(Succeed())
+		Expect(exported).To(Equal(manifest.Export(root, nil)))
+	})
+
+	It("exports JSON", func() {
+		root.Structure[0].Frontmatter = map[string]interface{}{"params": map[interface{}]interface{}{"github_branch": "master"}}
+		Expect(manifest.ExportTree(buf, root, manifest.ExportFormatJSON, nil)).To(Succeed())
+		exported := map[string]interface{}{}
+		Expect(json.Unmarshal(buf.Bytes(), &exported)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`"github_branch": "master"`))
+		Expect(buf.String()).To(ContainSubstring(`"destination": "foo/bar/renamed.md"`))
+	})
+
+	It("exports DOT", func() {
+		Expect(manifest.ExportTree(buf, root, manifest.ExportFormatDOT, nil)).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("digraph docforge {\n"))
+		Expect(buf.String()).To(ContainSubstring(`n1 [label="foo/", shape=folder];`))
+		Expect(buf.String()).To(ContainSubstring(`n0 -> n1;`))
+		Expect(buf.String()).To(ContainSubstring(`foo/bar/renamed.md\n(downloader)\nhttps://github.com/gardener/docforge/blob/master/contents/website/blog/2024/README.txt`))
+	})
+
+	It("fails for unsupported format", func() {
+		err := manifest.ExportTree(buf, root, "xml", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unsupported export format xml"))
+	})
+})
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FrontmatterFormatYAML is frontmatter between --- fences
+	FrontmatterFormatYAML = "yaml"
+	// FrontmatterFormatTOML is frontmatter between +++ fences
+	FrontmatterFormatTOML = "toml"
+	// FrontmatterFormatJSON is frontmatter as a JSON object at the start of the document
+	FrontmatterFormatJSON = "json"
+)
+
+// FrontmatterFormats lists the supported frontmatter formats
+func FrontmatterFormats() []string {
+	return []string{FrontmatterFormatYAML, FrontmatterFormatTOML, FrontmatterFormatJSON}
+}
+
+// MarshalFrontmatter returns the frontmatter block of a document in the given format
+func MarshalFrontmatter(fm map[string]interface{}, format string) ([]byte, error) {
+	b := &bytes.Buffer{}
+	switch format {
+	case FrontmatterFormatYAML, "":
+		cnt, err := yaml.Marshal(fm)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("---\n")
+		b.Write(cnt)
+		b.WriteString("---\n")
+	case FrontmatterFormatTOML:
+		cnt, err := toml.Marshal(NormalizeFrontmatter(fm))
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("+++\n")
+		b.Write(cnt)
+		b.WriteString("+++\n")
+	case FrontmatterFormatJSON:
+		cnt, err := json.MarshalIndent(NormalizeFrontmatter(fm), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		b.Write(cnt)
+		b.WriteString("\n")
+	default:
+		return nil, fmt.Errorf("unsupported frontmatter format %s", format)
+	}
+	return b.Bytes(), nil
+}
+
+// NormalizeFrontmatter converts the values decoded from YAML, TOML and JSON to the same types,
+// so that frontmatter is merged uniformly and can be encoded in any format. The maps decoded by
+// yaml.v2 with interface{} keys are converted to maps with string keys.
+func NormalizeFrontmatter(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			normalized[key] = NormalizeFrontmatter(val)
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			normalized[fmt.Sprint(key)] = NormalizeFrontmatter(val)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, val := range v {
+			normalized[i] = NormalizeFrontmatter(val)
+		}
+		return normalized
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case int64:
+		return int(v)
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalDateTime:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	default:
+		return value
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
 	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
 )
 
 const sectionFile = "_index.md"
@@ -27,6 +28,18 @@ type NodeTransformation func(node *Node, parent *Node, r registry.Interface) (ru
 
 type manifestToNodeTreeTransfromation func(node *Node, parent *Node, manifest *Node, r registry.Interface) error
 
+// chain applies functions one after another on a node before its children are processed
+func chain(functions ...manifestToNodeTreeTransfromation) manifestToNodeTreeTransfromation {
+	return func(node *Node, parent *Node, manifest *Node, r registry.Interface) error {
+		for _, f := range functions {
+			if err := f(node, parent, manifest, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 func manifestToNodeTree(manifest *Node, r registry.Interface, functions ...manifestToNodeTreeTransfromation) error {
 	for i := range functions {
 		if err := processManifestToNodeTreeTransfromation(functions[i], manifest, nil, manifest, r); err != nil {
@@ -143,7 +156,19 @@ func loadRepositoriesOfResources(node *Node, parent *Node, _ *Node, r registry.I
 	return loadErr
 }
 
-func readManifestContents(node *Node, parent *Node, manifest *Node, r registry.Interface) error {
+// manifestIncludes keeps track of the manifests included while building the node tree
+type manifestIncludes struct {
+	// maxDepth is the maximum depth of nested manifests, 0 means unlimited
+	maxDepth int
+	// included contains the already read manifests
+	included map[string]bool
+}
+
+func newManifestIncludes(maxDepth int) *manifestIncludes {
+	return &manifestIncludes{maxDepth: maxDepth, included: map[string]bool{}}
+}
+
+func (m *manifestIncludes) readManifestContents(node *Node, parent *Node, manifest *Node, r registry.Interface) error {
 	// skip non-manifest nodes
 	if node.Manifest == "" {
 		return nil
@@ -157,6 +182,26 @@ func readManifestContents(node *Node, parent *Node, manifest *Node, r registry.I
 		}
 		node.Manifest = manifestResourceURL
 	}
+	var ancestors []string
+	if node != manifest {
+		ancestors = manifest.includeChain
+	}
+	includeChain := append(slices.Clone(ancestors), node.Manifest)
+	if slices.Contains(ancestors, node.Manifest) {
+		return fmt.Errorf("manifest include cycle detected: %s", strings.Join(includeChain, " -> "))
+	}
+	if m.maxDepth > 0 && len(ancestors) > m.maxDepth {
+		return fmt.Errorf("manifest include depth exceeds the maximum of %d: %s", m.maxDepth, strings.Join(includeChain, " -> "))
+	}
+	// vars of the including manifest and the node itself override the ones declared in the manifest content
+	inheritedVars := mergeVars(manifest.Vars, node.Vars)
+	includeKey := fmt.Sprintf("%s %v", node.Manifest, inheritedVars)
+	if m.included[includeKey] {
+		klog.Warningf("manifest %s is included more than once, skipping the duplicate subtree included by %s", node.Manifest, strings.Join(ancestors, " -> "))
+		return nil
+	}
+	m.included[includeKey] = true
+	node.includeChain = includeChain
 	// load for the read to succeed
 	if err := r.LoadRepository(context.TODO(), node.Manifest); err != nil {
 		return err
@@ -168,6 +213,7 @@ func readManifestContents(node *Node, parent *Node, manifest *Node, r registry.I
 	if err = yaml.Unmarshal(byteContent, node); err != nil {
 		return fmt.Errorf("can't parse manifest %s yaml content : %w", node.Manifest, err)
 	}
+	node.Vars = mergeVars(node.Vars, inheritedVars)
 	return nil
 }
 
@@ -175,6 +221,10 @@ func removeManifestNodes(node *Node, parent *Node, _ *Node, r registry.Interface
 	if node.Type != "manifest" || parent == nil {
 		return nil
 	}
+	// the schema of a nested manifest applies to its nodes, which are moved to the parent
+	for _, child := range node.Structure {
+		child.FrontmatterSchema = node.FrontmatterSchema.Merge(child.FrontmatterSchema)
+	}
 	parent.Structure = append(parent.Structure, node.Structure...)
 	node.Structure = nil
 	RemoveNodeFromParent(node, parent)
@@ -404,15 +454,91 @@ func setDefaultProcessor(node *Node, parent *Node, _ registry.Interface) (bool,
 	return false, nil
 }
 
+// ResolveOptions are the options for resolving a manifest
+type ResolveOptions struct {
+	// Version pins the resources of the manifest to a given reference
+	Version *Version
+	// Vars are variables used for interpolation in the manifests.
+	// They take precedence over the vars declared in the manifests
+	Vars map[string]string
+	// MaxIncludeDepth is the maximum depth of nested manifests, 0 means unlimited
+	MaxIncludeDepth int
+	// Overlays are URLs of overlays patching the node tree of the manifest, applied in the given order
+	Overlays []string
+	// FrontmatterSchema applies to all documents, the schemas declared in the manifests extend it
+	FrontmatterSchema *FrontmatterSchema
+}
+
+// Version pins the resources of a manifest to a given reference
+type Version struct {
+	// Ref is the branch, tag or commit used instead of the declared resource references
+	Ref string
+	// Repositories are the repository URLs whose resource references are replaced with Ref.
+	// If empty, the repository of the manifest is used
+	Repositories []string
+}
+
+func replaceRefs(version Version) manifestToNodeTreeTransfromation {
+	return func(node *Node, _ *Node, _ *Node, _ registry.Interface) error {
+		replaceRef := func(link *string) error {
+			newLink, err := repositoryhost.ReplaceRef(*link, version.Ref, version.Repositories)
+			if err != nil {
+				return fmt.Errorf("can't replace ref of %s : %w", *link, err)
+			}
+			*link = newLink
+			return nil
+		}
+		replaceErr := errors.Join(replaceRef(&node.Manifest), replaceRef(&node.File), replaceRef(&node.Source), replaceRef(&node.FileTree))
+		for i := range node.MultiSource {
+			replaceErr = errors.Join(replaceErr, replaceRef(&node.MultiSource[i]))
+		}
+		return replaceErr
+	}
+}
+
+func versionRepositories(url string, version Version) ([]string, error) {
+	repositories := []string{}
+	for _, repository := range version.Repositories {
+		repositories = append(repositories, strings.TrimSuffix(repository, "/"))
+	}
+	if len(repositories) == 0 {
+		manifestRepository, err := repositoryhost.RepositoryURL(url)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, manifestRepository)
+	}
+	return repositories, nil
+}
+
 // ResolveManifest collects files in FileCollector from a given url and resourcehandlers.FileSource
 func ResolveManifest(url string, r registry.Interface, additionalTransformations ...NodeTransformation) ([]*Node, error) {
+	return ResolveManifestWithOptions(url, ResolveOptions{}, r, additionalTransformations...)
+}
+
+// ResolveManifestWithOptions resolves a manifest the same way as ResolveManifest, additionally interpolating
+// the given vars and replacing the references of the versioned resources, including nested manifests
+func ResolveManifestWithOptions(url string, opts ResolveOptions, r registry.Interface, additionalTransformations ...NodeTransformation) ([]*Node, error) {
+	readContents := []manifestToNodeTreeTransfromation{interpolateVars}
+	if opts.Version != nil {
+		version := *opts.Version
+		repositories, err := versionRepositories(url, version)
+		if err != nil {
+			return nil, err
+		}
+		version.Repositories = repositories
+		readContents = append(readContents, replaceRefs(version))
+	}
+	readContents = append(readContents, newManifestIncludes(opts.MaxIncludeDepth).readManifestContents)
+
 	manifest := &Node{
 		ManifType: ManifType{
 			Manifest: url,
 		},
+		Vars: mergeVars(opts.Vars, nil),
 	}
 	err := manifestToNodeTree(manifest, r,
-		readManifestContents,
+		chain(readContents...),
 		// needed for resolveManifestLinks during check if links point to existing resources
 		loadRepositoriesOfResources,
 		resolveManifestLinks,
@@ -422,6 +548,7 @@ func ResolveManifest(url string, r registry.Interface, additionalTransformations
 		return nil, err
 	}
 
+	manifest.FrontmatterSchema = opts.FrontmatterSchema.Merge(manifest.FrontmatterSchema)
 	err = processNodeTree(manifest, r, false,
 		decideNodeType,
 		validateTreeAfterManifestToNodeTree,
@@ -431,6 +558,21 @@ func ResolveManifest(url string, r registry.Interface, additionalTransformations
 	if err != nil {
 		return nil, err
 	}
+	if len(opts.Overlays) > 0 {
+		overlayTransformations := []NodeTransformation{}
+		for _, overlayURL := range opts.Overlays {
+			overlay, err := loadOverlay(overlayURL, manifest.Vars, r)
+			if err != nil {
+				return nil, err
+			}
+			overlayTransformations = append(overlayTransformations, overlayTransformation(overlayURL, overlay))
+		}
+		// inserted nodes need the same processing as the manifest nodes
+		overlayTransformations = append(overlayTransformations, removeFileTreeNodes, setDefaultProcessor)
+		if err = processNodeTree(manifest, r, true, overlayTransformations...); err != nil {
+			return nil, err
+		}
+	}
 	err = processNodeTree(manifest, r, true, additionalTransformations...)
 	if err != nil {
 		return nil, err
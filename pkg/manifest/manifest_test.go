@@ -7,6 +7,7 @@ package manifest_test
 import (
 	"embed"
 	"fmt"
+	"os"
 	"testing"
 
 	_ "embed"
@@ -64,6 +65,248 @@ var _ = Describe("Manifest test", func() {
 		Entry("covering fileTree filtering", "fileTree_filtering"),
 	)
 
+	Describe("When resolving a versioned manifest", func() {
+		It("replaces the references of the manifest repository resources", func() {
+			r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+			url := "https://github.com/gardener/docforge/blob/master/manifests/manifest.yaml"
+
+			allNodes, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{Version: &manifest.Version{Ref: "v1.0.0"}}, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			files := 0
+			for _, node := range allNodes {
+				if node.Type == "file" && node.Source != "" {
+					Expect(node.Source).To(HavePrefix("https://github.com/gardener/docforge/blob/v1.0.0/"))
+					files++
+				}
+			}
+			Expect(files).To(Equal(9))
+		})
+
+		It("keeps the references of resources from other repositories", func() {
+			r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+			url := "https://github.com/gardener/docforge/blob/master/manifests/manifest.yaml"
+
+			allNodes, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{Version: &manifest.Version{Ref: "v1.0.0", Repositories: []string{"https://github.com/gardener/gardener/"}}}, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			for _, node := range allNodes {
+				if node.Type == "file" && node.Source != "" {
+					Expect(node.Source).To(HavePrefix("https://github.com/gardener/docforge/blob/master/"))
+				}
+			}
+		})
+	})
+
+	Describe("When resolving a manifest with vars", func() {
+		var (
+			r   registry.Interface
+			url string
+		)
+
+		BeforeEach(func() {
+			r = registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+			url = "https://github.com/gardener/docforge/blob/master/manifests/vars.yaml"
+		})
+
+		It("interpolates vars in nested manifests and frontmatter", func() {
+			opts := manifest.ResolveOptions{Vars: map[string]string{"title": "Docs", "team": "gardener"}}
+
+			allNodes, err := manifest.ResolveManifestWithOptions(url, opts, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			files := map[string]*manifest.Node{}
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					files[node.Source] = node
+				}
+			}
+			Expect(files).To(HaveLen(2))
+			readme := files["https://github.com/gardener/docforge/blob/master/contents/README.txt"]
+			Expect(readme).NotTo(BeNil())
+			Expect(readme.Frontmatter["title"]).To(Equal("Docs"))
+			Expect(readme.Frontmatter["tags"]).To(Equal([]interface{}{"gardener"}))
+			concept := files["https://github.com/gardener/docforge/blob/master/contents/docs/architecture/concept.txt"]
+			Expect(concept).NotTo(BeNil())
+			Expect(concept.Frontmatter["title"]).To(Equal("Docs"))
+		})
+
+		It("falls back to environment variables", func() {
+			Expect(os.Setenv("team", "docs-team")).To(Succeed())
+			defer os.Unsetenv("team")
+
+			allNodes, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{}, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			for _, node := range allNodes {
+				if node.Type == "file" && node.Name() == "README.txt" {
+					Expect(node.Frontmatter["title"]).To(Equal("Default title"))
+					Expect(node.Frontmatter["tags"]).To(Equal([]interface{}{"docs-team"}))
+				}
+			}
+		})
+
+		It("fails on undefined vars", func() {
+			_, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{}, r)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("undefined variables [team]"))
+		})
+	})
+
+	Describe("When resolving a manifest with overlays", func() {
+		var (
+			r   registry.Interface
+			url string
+		)
+
+		BeforeEach(func() {
+			r = registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+			url = "https://github.com/gardener/docforge/blob/master/manifests/merging.yaml"
+		})
+
+		It("applies the overlay patches in order", func() {
+			opts := manifest.ResolveOptions{Overlays: []string{"https://github.com/gardener/docforge/blob/master/overlays/merging.yaml"}}
+
+			allNodes, err := manifest.ResolveManifestWithOptions(url, opts, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			files := map[string]*manifest.Node{}
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					files[node.NodePath()] = node
+				}
+			}
+			Expect(files).To(HaveLen(6))
+			Expect(files).NotTo(HaveKey("blog/2024/one"))
+			Expect(files).NotTo(HaveKey("blog/foo.txt"))
+			Expect(files["blog/2024/foo.txt"].Frontmatter).To(HaveKeyWithValue("team", "docs"))
+			Expect(files["blog/2024/two.txt"].Frontmatter).To(HaveKeyWithValue("team", "docs"))
+			Expect(files["blog/2024/README.txt"].Frontmatter).NotTo(HaveKey("team"))
+			Expect(files["blog/bar.txt"].Source).To(Equal("https://github.com/gardener/docforge/blob/master/contents/README.txt"))
+			Expect(files["blog/bar.txt"].Processor).To(Equal("downloader"))
+			Expect(files).To(HaveKey("blog/2024/architecture/README.txt"))
+			Expect(files).To(HaveKey("blog/2024/architecture/concept.txt"))
+		})
+
+		It("fails when a patch matches no nodes", func() {
+			opts := manifest.ResolveOptions{Overlays: []string{"https://github.com/gardener/docforge/blob/master/overlays/unmatched.yaml"}}
+
+			_, err := manifest.ResolveManifestWithOptions(url, opts, r)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("patch 0 matches no nodes"))
+		})
+	})
+
+	Describe("When resolving nested manifests", func() {
+		var r registry.Interface
+
+		BeforeEach(func() {
+			r = registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		})
+
+		It("reports include cycles with the full chain", func() {
+			url := "https://github.com/gardener/docforge/blob/master/manifests/cycle_a.yaml"
+
+			_, err := manifest.ResolveManifest(url, r)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("manifest include cycle detected: " +
+				"https://github.com/gardener/docforge/blob/master/manifests/cycle_a.yaml -> " +
+				"https://github.com/gardener/docforge/blob/master/manifests/cycle_b.yaml -> " +
+				"https://github.com/gardener/docforge/blob/master/manifests/cycle_a.yaml"))
+		})
+
+		It("fails when the max include depth is exceeded", func() {
+			url := "https://github.com/gardener/docforge/blob/master/manifests/nested_includes.yaml"
+
+			_, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{MaxIncludeDepth: 1}, r)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("manifest include depth exceeds the maximum of 1"))
+		})
+
+		It("allows includes within the max depth", func() {
+			url := "https://github.com/gardener/docforge/blob/master/manifests/nested_includes.yaml"
+
+			_, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{MaxIncludeDepth: 2}, r)
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("includes a manifest included twice only once", func() {
+			url := "https://github.com/gardener/docforge/blob/master/manifests/duplicate_includes.yaml"
+
+			allNodes, err := manifest.ResolveManifest(url, r)
+
+			Expect(err).ToNot(HaveOccurred())
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					Expect(node.Path).To(HavePrefix("first"))
+				}
+			}
+		})
+	})
+
+	Describe("When resolving a manifest with frontmatter schemas", func() {
+		var (
+			schemas map[string]*manifest.FrontmatterSchema
+			err     error
+		)
+
+		BeforeEach(func() {
+			r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+			url := "https://github.com/gardener/docforge/blob/master/manifests/frontmatter_schema.yaml"
+			opts := manifest.ResolveOptions{FrontmatterSchema: &manifest.FrontmatterSchema{Required: []string{"weight"}}}
+			var allNodes []*manifest.Node
+			allNodes, err = manifest.ResolveManifestWithOptions(url, opts, r)
+			schemas = map[string]*manifest.FrontmatterSchema{}
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					schemas[node.Name()] = manifest.FrontmatterSchemaOf(node)
+				}
+			}
+		})
+
+		It("merges the schemas of the ancestors", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schemas["README.txt"].Required).To(Equal([]string{"weight", "title"}))
+			Expect(schemas["concept.txt"].Required).To(Equal([]string{"weight", "title", "description"}))
+			Expect(schemas["concept.txt"].Properties).To(HaveKey("persona"))
+		})
+
+		It("applies the schemas of nested manifests", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schemas["two.txt"].Required).To(Equal([]string{"weight", "title", "author"}))
+		})
+
+		It("reports all violations", func() {
+			Expect(err).ToNot(HaveOccurred())
+			validationErr := schemas["concept.txt"].Validate(map[string]interface{}{
+				"title":       "Concept",
+				"weight":      1,
+				"persona":     []interface{}{"Users", "Devs"},
+				"publishdate": "01.05.2024",
+			})
+			Expect(validationErr).To(HaveOccurred())
+			Expect(validationErr.Error()).To(Equal("required key description is missing\n" +
+				"key persona: value Devs is not one of [Users Operators Developers]\n" +
+				"key publishdate: date 01.05.2024 does not match the format 2006-01-02 or 2006-01-02T15:04:05Z07:00"))
+		})
+
+		It("accepts valid frontmatter", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schemas["concept.txt"].Validate(map[string]interface{}{
+				"title":       "Concept",
+				"description": "Architecture concept",
+				"weight":      1,
+				"persona":     "Operators",
+				"publishdate": "2024-05-01",
+			})).To(Succeed())
+		})
+	})
+
 	Describe("When there are dirs with frontmatter collision", func() {
 		It("should fail", func() {
 			r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
@@ -12,6 +12,16 @@ type FileType struct {
 	Source string `yaml:"source,omitempty"`
 	// MultiSource is a file build from multiple sources
 	MultiSource []string `yaml:"multiSource,omitempty"`
+	// MultiSourceOptions control how the MultiSource documents are concatenated
+	MultiSourceOptions *MultiSourceOptions `yaml:"multiSourceOptions,omitempty"`
+}
+
+// MultiSourceOptions control how the markdown documents of a multiSource file are concatenated
+type MultiSourceOptions struct {
+	// ShiftHeadings is the number of levels the headings of all but the first document are shifted with
+	ShiftHeadings int `yaml:"shiftHeadings,omitempty"`
+	// Separator is written between the documents, e.g. a thematic break ---
+	Separator string `yaml:"separator,omitempty"`
 }
 
 // DirType represents a directory node
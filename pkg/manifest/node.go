@@ -28,14 +28,20 @@ type Node struct {
 	SkipValidation bool `yaml:"skipValidation,omitempty"`
 	// Frontmatter of the node
 	Frontmatter map[string]interface{} `yaml:"frontmatter,omitempty"`
+	// FrontmatterSchema is validated against the frontmatter of the documents in the node subtree
+	FrontmatterSchema *FrontmatterSchema `yaml:"frontmatterSchema,omitempty"`
 	// Type of node
 	Type string `yaml:"type,omitempty"`
 	// Path of node
 	Path string `yaml:"path,omitempty"`
 	// LinkResolution describes how links should be resolved when processing the given node
 	LinkResolution map[string]string `yaml:"linkResolution,omitempty"`
+	// Vars are the variables available for interpolation in a manifest node and its content
+	Vars map[string]string `yaml:"vars,omitempty"`
 	// Parent of node
 	parent *Node
+	// includeChain are the URLs of the manifests including a manifest node, ending with the node manifest
+	includeChain []string
 }
 
 // Name is the name of the node
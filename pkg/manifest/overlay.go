@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/gardener/docforge/pkg/registry"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// OverlayOpRemove removes the selected nodes
+	OverlayOpRemove = "remove"
+	// OverlayOpReplace replaces the selected nodes with the patch nodes
+	OverlayOpReplace = "replace"
+	// OverlayOpMergeFrontmatter merges the patch frontmatter into the frontmatter of the selected nodes
+	OverlayOpMergeFrontmatter = "mergeFrontmatter"
+	// OverlayOpInsertAfter inserts the patch nodes after the selected nodes
+	OverlayOpInsertAfter = "insertAfter"
+)
+
+// Overlay patches the node tree of a base manifest
+type Overlay struct {
+	// Patches are applied in the order they are declared
+	Patches []*Patch `yaml:"patches"`
+}
+
+// Patch selects nodes by path or source glob and applies an operation on them
+type Patch struct {
+	// Path is a glob matched against the node path in the bundle, e.g. docs/guides/*.md
+	Path string `yaml:"path,omitempty"`
+	// Source is a glob matched against the node source and multiSource URLs
+	Source string `yaml:"source,omitempty"`
+	// Op is one of remove, replace, mergeFrontmatter or insertAfter
+	Op string `yaml:"op"`
+	// Frontmatter merged into the selected nodes by the mergeFrontmatter operation
+	Frontmatter map[string]interface{} `yaml:"frontmatter,omitempty"`
+	// Nodes used by the replace and insertAfter operations
+	Nodes []*Node `yaml:"nodes,omitempty"`
+}
+
+// loadOverlay reads an overlay and resolves the links of its nodes relative to the overlay URL
+func loadOverlay(url string, vars map[string]string, r registry.Interface) (*Overlay, error) {
+	if err := r.LoadRepository(context.TODO(), url); err != nil {
+		return nil, err
+	}
+	byteContent, err := r.Read(context.TODO(), url)
+	if err != nil {
+		return nil, fmt.Errorf("can't get overlay file content : %w", err)
+	}
+	overlay := &Overlay{}
+	if err = yaml.Unmarshal(byteContent, overlay); err != nil {
+		return nil, fmt.Errorf("can't parse overlay %s yaml content : %w", url, err)
+	}
+	for i, patch := range overlay.Patches {
+		if err := patch.validate(); err != nil {
+			return nil, fmt.Errorf("overlay %s patch %d : %w", url, i, err)
+		}
+		if len(patch.Nodes) == 0 {
+			continue
+		}
+		// the patch nodes are resolved as the structure of a manifest located at the overlay URL
+		overlayManifest := &Node{
+			ManifType: ManifType{Manifest: url},
+			Vars:      mergeVars(vars, nil),
+		}
+		overlayManifest.Structure = patch.Nodes
+		functions := []manifestToNodeTreeTransfromation{
+			chain(interpolateVars, newManifestIncludes(0).readManifestContents),
+			loadRepositoriesOfResources,
+			resolveManifestLinks,
+			removeManifestNodes,
+		}
+		for _, f := range functions {
+			for _, node := range overlayManifest.Structure {
+				if err := processManifestToNodeTreeTransfromation(f, node, overlayManifest, overlayManifest, r); err != nil {
+					return nil, fmt.Errorf("overlay %s -> %w", url, err)
+				}
+			}
+		}
+		for _, node := range overlayManifest.Structure {
+			if _, err := processTransformation(decideNodeType, node, overlayManifest, r); err != nil {
+				return nil, fmt.Errorf("overlay %s -> %w", url, err)
+			}
+		}
+		patch.Nodes = overlayManifest.Structure
+	}
+	return overlay, nil
+}
+
+func (p *Patch) validate() error {
+	if p.Path == "" && p.Source == "" {
+		return fmt.Errorf("no path or source selector")
+	}
+	switch p.Op {
+	case OverlayOpRemove:
+		return nil
+	case OverlayOpMergeFrontmatter:
+		if len(p.Frontmatter) == 0 {
+			return fmt.Errorf("operation %s requires frontmatter", p.Op)
+		}
+		return nil
+	case OverlayOpReplace, OverlayOpInsertAfter:
+		if len(p.Nodes) == 0 {
+			return fmt.Errorf("operation %s requires nodes", p.Op)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown operation %q", p.Op)
+	}
+}
+
+func (p *Patch) matches(node *Node) bool {
+	if p.Path != "" {
+		if node.Type != "file" && node.Type != "dir" {
+			return false
+		}
+		if matched, _ := path.Match(p.Path, node.NodePath()); !matched {
+			return false
+		}
+	}
+	if p.Source != "" {
+		for _, source := range append([]string{node.Source}, node.MultiSource...) {
+			if matched, _ := path.Match(p.Source, source); matched && source != "" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// apply patches the structure of node and returns the number of matched nodes
+func (p *Patch) apply(node *Node) (int, error) {
+	matched := 0
+	structure := []*Node{}
+	for _, child := range node.Structure {
+		if !p.matches(child) {
+			childMatched, err := p.apply(child)
+			if err != nil {
+				return matched, err
+			}
+			matched += childMatched
+			structure = append(structure, child)
+			continue
+		}
+		matched++
+		switch p.Op {
+		case OverlayOpReplace:
+			nodes, err := copyNodes(p.Nodes)
+			if err != nil {
+				return matched, err
+			}
+			structure = append(structure, nodes...)
+		case OverlayOpInsertAfter:
+			nodes, err := copyNodes(p.Nodes)
+			if err != nil {
+				return matched, err
+			}
+			structure = append(structure, child)
+			structure = append(structure, nodes...)
+		case OverlayOpMergeFrontmatter:
+			if child.Frontmatter == nil {
+				child.Frontmatter = map[string]interface{}{}
+			}
+			for k, v := range p.Frontmatter {
+				child.Frontmatter[k] = v
+			}
+			structure = append(structure, child)
+		}
+	}
+	node.Structure = structure
+	return matched, nil
+}
+
+// copyNodes deep copies nodes so that a patch matching several nodes doesn't share them
+func copyNodes(nodes []*Node) ([]*Node, error) {
+	content, err := yaml.Marshal(nodes)
+	if err != nil {
+		return nil, err
+	}
+	copied := []*Node{}
+	if err := yaml.Unmarshal(content, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// overlayTransformation applies the overlay patches on the whole node tree starting from the root node
+func overlayTransformation(url string, overlay *Overlay) NodeTransformation {
+	return func(node *Node, parent *Node, _ registry.Interface) (bool, error) {
+		if parent != nil {
+			return false, nil
+		}
+		for i, patch := range overlay.Patches {
+			matched, err := patch.apply(node)
+			if err != nil {
+				return true, fmt.Errorf("overlay %s patch %d : %w", url, i, err)
+			}
+			if matched == 0 {
+				return true, fmt.Errorf("overlay %s patch %d matches no nodes", url, i)
+			}
+		}
+		return true, nil
+	}
+}
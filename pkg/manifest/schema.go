@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FrontmatterSchema declares the frontmatter of the documents in a subtree
+type FrontmatterSchema struct {
+	// Required are the keys every document must have
+	Required []string `yaml:"required,omitempty" mapstructure:"required"`
+	// Properties constrain the values of frontmatter keys
+	Properties map[string]*FrontmatterProperty `yaml:"properties,omitempty" mapstructure:"properties"`
+}
+
+// FrontmatterProperty constrains the value of a frontmatter key
+type FrontmatterProperty struct {
+	// Type is one of string, integer, number, boolean, array, object or date
+	Type string `yaml:"type,omitempty" mapstructure:"type"`
+	// Enum lists the allowed values. The elements of arrays are checked one by one
+	Enum []interface{} `yaml:"enum,omitempty" mapstructure:"enum"`
+	// Format is the Go time layout of date strings, 2006-01-02 or RFC 3339 when not set
+	Format string `yaml:"format,omitempty" mapstructure:"format"`
+}
+
+// Merge returns a schema with the required keys of both schemas and the properties
+// of s overridden by the ones of other
+func (s *FrontmatterSchema) Merge(other *FrontmatterSchema) *FrontmatterSchema {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+	merged := &FrontmatterSchema{
+		Required:   slices.Clone(s.Required),
+		Properties: map[string]*FrontmatterProperty{},
+	}
+	for _, key := range other.Required {
+		if !slices.Contains(merged.Required, key) {
+			merged.Required = append(merged.Required, key)
+		}
+	}
+	for key, property := range s.Properties {
+		merged.Properties[key] = property
+	}
+	for key, property := range other.Properties {
+		merged.Properties[key] = property
+	}
+	return merged
+}
+
+// FrontmatterSchemaOf returns the frontmatter schema of a node, merged from the schemas
+// declared on the node and its ancestors, the nearest ones taking precedence
+func FrontmatterSchemaOf(node *Node) *FrontmatterSchema {
+	var schema *FrontmatterSchema
+	for n := node; n != nil; n = n.Parent() {
+		schema = n.FrontmatterSchema.Merge(schema)
+	}
+	return schema
+}
+
+// Validate checks frontmatter against the schema and returns all violations
+func (s *FrontmatterSchema) Validate(frontmatter map[string]interface{}) error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	for _, key := range s.Required {
+		if _, ok := frontmatter[key]; !ok {
+			errs = append(errs, fmt.Errorf("required key %s is missing", key))
+		}
+	}
+	keys := make([]string, 0, len(s.Properties))
+	for key := range s.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := frontmatter[key]
+		if !ok {
+			continue
+		}
+		if err := s.Properties[key].validate(value); err != nil {
+			errs = append(errs, fmt.Errorf("key %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *FrontmatterProperty) validate(value interface{}) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.validateType(value); err != nil {
+		return err
+	}
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	values := []interface{}{value}
+	if array, ok := value.([]interface{}); ok {
+		values = array
+	}
+	for _, v := range values {
+		allowed := slices.ContainsFunc(p.Enum, func(e interface{}) bool {
+			return fmt.Sprint(e) == fmt.Sprint(v)
+		})
+		if !allowed {
+			return fmt.Errorf("value %v is not one of %v", v, p.Enum)
+		}
+	}
+	return nil
+}
+
+//gocyclo:ignore
+func (p *FrontmatterProperty) validateType(value interface{}) error {
+	valid := true
+	switch p.Type {
+	case "":
+	case "string":
+		_, valid = value.(string)
+	case "integer":
+		switch v := value.(type) {
+		case int, int64, uint64:
+		case float64:
+			valid = v == float64(int64(v))
+		default:
+			valid = false
+		}
+	case "number":
+		switch value.(type) {
+		case int, int64, uint64, float64:
+		default:
+			valid = false
+		}
+	case "boolean":
+		_, valid = value.(bool)
+	case "array":
+		_, valid = value.([]interface{})
+	case "object":
+		switch value.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+		default:
+			valid = false
+		}
+	case "date":
+		return p.validateDate(value)
+	default:
+		return fmt.Errorf("unknown type %s in schema", p.Type)
+	}
+	if !valid {
+		return fmt.Errorf("value %v is not of type %s", value, p.Type)
+	}
+	return nil
+}
+
+func (p *FrontmatterProperty) validateDate(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		return nil
+	case string:
+		layouts := []string{"2006-01-02", time.RFC3339}
+		if p.Format != "" {
+			layouts = []string{p.Format}
+		}
+		for _, layout := range layouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("date %s does not match the format %s", v, strings.Join(layouts, " or "))
+	default:
+		return fmt.Errorf("value %v is not of type date", value)
+	}
+}
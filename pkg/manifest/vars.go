@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/gardener/docforge/pkg/registry"
+)
+
+// defines a ${var} reference
+var varReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.\-]*)\}`)
+
+// mergeVars returns a new map with the vars from base overridden by the vars from override
+func mergeVars(base map[string]string, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// interpolate replaces ${var} references in value with vars values, falling back to environment variables
+func interpolate(value string, vars map[string]string) (string, error) {
+	var undefined []string
+	interpolated := varReference.ReplaceAllStringFunc(value, func(reference string) string {
+		name := varReference.FindStringSubmatch(reference)[1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		undefined = append(undefined, name)
+		return reference
+	})
+	if len(undefined) > 0 {
+		return value, fmt.Errorf("undefined variables %v in %s", undefined, value)
+	}
+	return interpolated, nil
+}
+
+func interpolateValue(value interface{}, vars map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return interpolate(v, vars)
+	case map[string]interface{}:
+		for key, val := range v {
+			interpolated, err := interpolateValue(val, vars)
+			if err != nil {
+				return v, err
+			}
+			v[key] = interpolated
+		}
+	case map[interface{}]interface{}:
+		for key, val := range v {
+			interpolated, err := interpolateValue(val, vars)
+			if err != nil {
+				return v, err
+			}
+			v[key] = interpolated
+		}
+	case []interface{}:
+		for i, val := range v {
+			interpolated, err := interpolateValue(val, vars)
+			if err != nil {
+				return v, err
+			}
+			v[i] = interpolated
+		}
+	}
+	return value, nil
+}
+
+// interpolateVars replaces the ${var} references in the node fields with the vars of the manifest the node belongs to
+func interpolateVars(node *Node, _ *Node, manifest *Node, _ registry.Interface) error {
+	interpolateField := func(field *string) error {
+		interpolated, err := interpolate(*field, manifest.Vars)
+		*field = interpolated
+		return err
+	}
+	interpolateErr := errors.Join(interpolateField(&node.Manifest), interpolateField(&node.File), interpolateField(&node.Source), interpolateField(&node.FileTree))
+	for i := range node.MultiSource {
+		interpolateErr = errors.Join(interpolateErr, interpolateField(&node.MultiSource[i]))
+	}
+	if _, err := interpolateValue(node.Frontmatter, manifest.Vars); err != nil {
+		interpolateErr = errors.Join(interpolateErr, err)
+	}
+	if interpolateErr != nil {
+		return fmt.Errorf("failed to interpolate vars in manifest %s: %w", manifest.Manifest, interpolateErr)
+	}
+	return nil
+}
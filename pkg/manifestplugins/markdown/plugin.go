@@ -15,8 +15,10 @@ func (d *Markdown) PluginNodeTransformations() []manifest.NodeTransformation {
 	return []manifest.NodeTransformation{setMarkdownProcessor, propagateFrontmatter, propagateSkipValidation}
 }
 
+// setMarkdownProcessor processes the markdown files with the markdown processor, unless another processor
+// is set in the manifest, e.g. the changelog processor generating the page
 func setMarkdownProcessor(node *manifest.Node, parent *manifest.Node, _ registry.Interface) (bool, error) {
-	if node.Type == "file" && strings.HasSuffix(node.File, ".md") {
+	if node.Type == "file" && strings.HasSuffix(node.File, ".md") && node.Processor == "downloader" {
 		node.Processor = "markdown"
 	}
 	return false, nil
@@ -67,4 +67,18 @@ var _ = Describe("Markdown test", func() {
 		Entry("covering type file", "file"),
 		Entry("covering multisource", "multisource"),
 	)
+
+	It("keeps the processors set in the manifest", func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		markdownPlugin := markdown.Markdown{}
+		allNodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/manifests/processor.yaml", r, markdownPlugin.PluginNodeTransformations()...)
+		Expect(err).ToNot(HaveOccurred())
+		processors := map[string]string{}
+		for _, node := range allNodes {
+			if node.Type == "file" {
+				processors[node.File] = node.Processor
+			}
+		}
+		Expect(processors).To(Equal(map[string]string{"foo.md": "markdown", "whats-new.md": "changelog"}))
+	})
 })
@@ -0,0 +1,49 @@
+package multiversion
+
+import (
+	"encoding/json"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/registry"
+)
+
+// VersionsIndexFile is the name of the file listing all built versions
+const VersionsIndexFile = "versions.json"
+
+// MultiVersion is the object representing the multi version plugin
+type MultiVersion struct {
+	Version string
+}
+
+// PluginNodeTransformations returns the node transformations for the multi version plugin
+func (d *MultiVersion) PluginNodeTransformations() []manifest.NodeTransformation {
+	return []manifest.NodeTransformation{d.setVersionFrontmatter}
+}
+
+func (d *MultiVersion) setVersionFrontmatter(node *manifest.Node, _ *manifest.Node, _ registry.Interface) (bool, error) {
+	if node.Type != "file" {
+		return false, nil
+	}
+	if node.Frontmatter == nil {
+		node.Frontmatter = map[string]interface{}{}
+	}
+	node.Frontmatter["version"] = d.Version
+	return false, nil
+}
+
+type versionEntry struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+// VersionsIndex returns the content of the versions index file listing the built versions
+// with their path relative to the destination
+func VersionsIndex(versions []string) ([]byte, error) {
+	index := struct {
+		Versions []versionEntry `json:"versions"`
+	}{Versions: []versionEntry{}}
+	for _, version := range versions {
+		index.Versions = append(index.Versions, versionEntry{Version: version, Path: version})
+	}
+	return json.MarshalIndent(index, "", "  ")
+}
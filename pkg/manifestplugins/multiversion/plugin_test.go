@@ -0,0 +1,54 @@
+package multiversion_test
+
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+import (
+	"embed"
+	"testing"
+
+	_ "embed"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/manifestplugins/multiversion"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMultiVersionPlugin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MultiVersion Suite")
+}
+
+//go:embed all:tests/*
+var repo embed.FS
+
+var _ = Describe("MultiVersion test", func() {
+	It("marks the version in the frontmatter of all files", func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		url := "https://github.com/gardener/docforge/blob/master/manifests/file.yaml"
+		multiVersionPlugin := multiversion.MultiVersion{Version: "v1.80"}
+		allNodes, err := manifest.ResolveManifestWithOptions(url, manifest.ResolveOptions{Version: &manifest.Version{Ref: "v1.80"}}, r, multiVersionPlugin.PluginNodeTransformations()...)
+		Expect(err).ToNot(HaveOccurred())
+		files := 0
+		for _, node := range allNodes {
+			if node.Type == "file" {
+				Expect(node.Frontmatter).To(HaveKeyWithValue("version", "v1.80"))
+				Expect(node.Frontmatter).To(HaveKey("title"))
+				files++
+			} else {
+				Expect(node.Frontmatter).NotTo(HaveKey("version"))
+			}
+		}
+		Expect(files).To(Equal(2))
+	})
+
+	It("builds the versions index", func() {
+		index, err := multiversion.VersionsIndex([]string{"v1.80", "master"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(index)).To(Equal("{\n  \"versions\": [\n    {\n      \"version\": \"v1.80\",\n      \"path\": \"v1.80\"\n    },\n    {\n      \"version\": \"master\",\n      \"path\": \"master\"\n    }\n  ]\n}"))
+	})
+})
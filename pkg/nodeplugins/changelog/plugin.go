@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/frontmatter"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// Processor is the name of the changelog processor
+const Processor = "changelog"
+
+// Plugin generates the changelog pages, which list the documents added or modified in the last days
+// grouped by section. The changelog nodes are recorded when they are processed and their pages are
+// written by the post-processing, once the git info of all documents is collected.
+type Plugin struct {
+	Resolver linkresolver.Interface
+	Writer   writers.Writer
+	Hugo     hugo.Hugo
+	// Days is the number of days listed by the changelog pages
+	Days int
+
+	changelogs []*manifest.Node
+}
+
+// Processor returns the changelog processor
+func (Plugin) Processor() string {
+	return Processor
+}
+
+// Process records a changelog node, whose page is written by PostProcess
+func (p *Plugin) Process(node *manifest.Node) error {
+	p.changelogs = append(p.changelogs, node)
+	return nil
+}
+
+// HasChangelogs checks if there are nodes processed by the changelog processor
+func HasChangelogs(nodes []*manifest.Node) bool {
+	return slices.ContainsFunc(nodes, func(node *manifest.Node) bool {
+		return node.Type == "file" && node.Processor == Processor
+	})
+}
+
+// change is a document added or modified in the listed days
+type change struct {
+	node  *manifest.Node
+	title string
+	link  string
+	added bool
+	date  string
+	info  *repositoryhost.GitInfo
+}
+
+// Name returns the name of the changelog post-processor
+func (Plugin) Name() string {
+	return Processor
+}
+
+// PostProcess writes the pages of the processed changelog nodes from the git info of the documents
+func (p *Plugin) PostProcess(_ context.Context, bundle *postprocessors.Bundle) error {
+	for _, node := range p.changelogs {
+		content, err := p.page(node, bundle)
+		if err != nil {
+			return fmt.Errorf("failed to generate changelog %s: %w", node.NodePath(), err)
+		}
+		if err = p.Writer.Write(node.Name(), node.Path, content, node, p.Hugo.IndexFileNames); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// page returns the markdown page of a changelog node
+func (p *Plugin) page(node *manifest.Node, bundle *postprocessors.Bundle) ([]byte, error) {
+	now := bundle.Time
+	since := now.AddDate(0, 0, -p.Days)
+	sections := map[string][]*change{}
+	for document, info := range bundle.GitInfos {
+		lastmod, err := time.Parse(repositoryhost.DateFormat, info.GetLastModifiedDate())
+		if err != nil || lastmod.Before(since) {
+			continue
+		}
+		source := document.Source
+		if source == "" && len(document.MultiSource) > 0 {
+			source = document.MultiSource[0]
+		}
+		if source == "" {
+			continue
+		}
+		link, err := p.Resolver.ResolveResourceLink(source, node, source)
+		if err != nil {
+			return nil, err
+		}
+		c := &change{node: document, title: title(document, bundle.Output(document), p.Hugo.IndexFileNames), link: link, date: info.GetLastModifiedDate(), info: info}
+		if published, err := time.Parse(repositoryhost.DateFormat, info.GetPublishDate()); err == nil && !published.Before(since) {
+			c.added, c.date = true, info.GetPublishDate()
+		}
+		sections[document.Path] = append(sections[document.Path], c)
+	}
+
+	fm := map[string]interface{}{"title": "What's New"}
+	maps.Copy(fm, node.Frontmatter)
+	page := &bytes.Buffer{}
+	frontmatter, err := manifest.MarshalFrontmatter(fm, p.Hugo.FrontmatterFormat)
+	if err != nil {
+		return nil, err
+	}
+	page.Write(frontmatter)
+	fmt.Fprintf(page, "\nDocuments added or modified in the %d days before %s.\n", p.Days, now.Format("2006-01-02"))
+	if len(sections) == 0 {
+		fmt.Fprintf(page, "\nNo documents were added or modified.\n")
+	}
+	paths := make([]string, 0, len(sections))
+	for path := range sections {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		changes := sections[path]
+		// the latest changes come first
+		slices.SortFunc(changes, func(a, b *change) int {
+			if a.date != b.date {
+				return strings.Compare(b.date, a.date)
+			}
+			return strings.Compare(a.title, b.title)
+		})
+		if heading := sectionTitle(changes[0].node); heading != "" {
+			fmt.Fprintf(page, "\n## %s\n", heading)
+		}
+		page.WriteString("\n")
+		for _, c := range changes {
+			action := "modified"
+			if c.added {
+				action = "added"
+			}
+			fmt.Fprintf(page, "- [%s](%s) %s on %s", c.title, c.link, action, strings.Fields(c.date)[0])
+			if c.added && c.info.Author != nil {
+				author := c.info.Author.GetName()
+				if author == "" {
+					author = c.info.Author.GetLogin()
+				}
+				fmt.Fprintf(page, " by %s", author)
+			}
+			page.WriteString("\n")
+		}
+	}
+	return page.Bytes(), nil
+}
+
+// title returns the title of a written document or computes it from its name the same way as the title
+// of documents without a title
+func title(node *manifest.Node, output *postprocessors.Output, indexFileNames []string) string {
+	var fm map[string]interface{}
+	if output != nil && output.Document != nil {
+		fm = output.Document.Frontmatter
+	}
+	return frontmatter.Title(fm, node, indexFileNames)
+}
+
+// sectionTitle returns the title of the section of a document, which is empty for documents at the root
+func sectionTitle(node *manifest.Node) string {
+	parent := node.Parent()
+	if parent == nil || parent.Type != "dir" {
+		return ""
+	}
+	return frontmatter.Humanize(parent.Name())
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changelog_test
+
+import (
+	"context"
+	"embed"
+	"testing"
+	"time"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/changelog"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestChangelogPlugin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Changelog Suite")
+}
+
+//go:embed all:tests/*
+var repo embed.FS
+
+var _ = Describe("Changelog", func() {
+	var (
+		nodes   []*manifest.Node
+		upgrade *manifest.Node
+		infos   map[*manifest.Node]*repositoryhost.GitInfo
+		writer  *writersfakes.FakeWriter
+		plugin  *changelog.Plugin
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		var err error
+		nodes, err = manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/manifest.yaml", r)
+		Expect(err).ToNot(HaveOccurred())
+		infos = map[*manifest.Node]*repositoryhost.GitInfo{}
+		for _, node := range nodes {
+			switch node.Name() {
+			case "intro.md":
+				infos[node] = &repositoryhost.GitInfo{LastModifiedDate: github.String("2025-05-20 10:00:00"), PublishDate: github.String("2024-01-01 10:00:00")}
+			case "install.md":
+				infos[node] = &repositoryhost.GitInfo{LastModifiedDate: github.String("2025-05-25 10:00:00"), PublishDate: github.String("2025-05-10 10:00:00"), Author: &github.User{Login: github.String("one")}}
+			case "upgrade.md":
+				upgrade = node
+				infos[node] = &repositoryhost.GitInfo{LastModifiedDate: github.String("2025-05-30 10:00:00"), PublishDate: github.String("2024-01-01 10:00:00")}
+			case "old_setup.md":
+				infos[node] = &repositoryhost.GitInfo{LastModifiedDate: github.String("2025-01-01 10:00:00"), PublishDate: github.String("2024-01-01 10:00:00")}
+			}
+		}
+		writer = &writersfakes.FakeWriter{}
+		plugin = &changelog.Plugin{Resolver: linkresolver.New(nodes, r, hugo.Hugo{}), Writer: writer, Days: 30}
+		now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("writes the documents added or modified in the last days grouped by section", func() {
+		Expect(changelog.HasChangelogs(nodes)).To(BeTrue())
+		for _, node := range nodes {
+			if node.Type == "file" && node.Processor == plugin.Processor() {
+				Expect(plugin.Process(node)).To(Succeed())
+			}
+		}
+		bundle := &postprocessors.Bundle{Nodes: nodes, GitInfos: infos, Time: now, Outputs: []*postprocessors.Output{
+			{Path: "guides/upgrade.md", Node: upgrade, Document: &postprocessors.Document{Frontmatter: map[string]interface{}{"title": "Upgrading Clusters"}}},
+		}}
+		Expect(postprocessors.Run(context.TODO(), bundle, plugin)).To(Succeed())
+		Expect(writer.WriteCallCount()).To(Equal(1))
+		name, path, content, _, _ := writer.WriteArgsForCall(0)
+		Expect(name).To(Equal("whats-new.md"))
+		Expect(path).To(Equal("."))
+		Expect(string(content)).To(Equal(`---
+title: Changes
+---
+
+Documents added or modified in the 30 days before 2025-06-01.
+
+- [Intro](/intro.md) modified on 2025-05-20
+
+## Guides
+
+- [Upgrading Clusters](/guides/upgrade.md) modified on 2025-05-30
+- [Install](/guides/install.md) added on 2025-05-10 by one
+`))
+	})
+
+	It("writes a page without documents when nothing changed", func() {
+		for _, node := range nodes {
+			if node.Processor == plugin.Processor() {
+				Expect(plugin.Process(node)).To(Succeed())
+			}
+		}
+		Expect(plugin.PostProcess(context.TODO(), &postprocessors.Bundle{Time: now})).To(Succeed())
+		_, _, content, _, _ := writer.WriteArgsForCall(0)
+		Expect(string(content)).To(HaveSuffix("Documents added or modified in the 30 days before 2025-06-01.\n\nNo documents were added or modified.\n"))
+	})
+})
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"k8s.io/klog/v2"
+)
+
+// Codeowners are the rules of a CODEOWNERS file
+type Codeowners struct {
+	rules []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Parse reads the rules of a CODEOWNERS file. Invalid patterns are skipped like GitHub does.
+func Parse(content []byte) *Codeowners {
+	c := &Codeowners{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(stripComment(line))
+		pattern, err := patternRegexp(fields[0])
+		if err != nil {
+			klog.Warningf("skipping CODEOWNERS pattern %s: %v", fields[0], err)
+			continue
+		}
+		c.rules = append(c.rules, rule{pattern: pattern, owners: fields[1:]})
+	}
+	return c
+}
+
+// stripComment removes a trailing comment from a line. A # escaped as \# is part of the pattern.
+func stripComment(line string) string {
+	for i := 1; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Owners returns the owners of a path relative to the repository root. The last matching rule
+// takes precedence and a matching rule without owners leaves the path without owners.
+func (c *Codeowners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// patternRegexp converts a CODEOWNERS pattern to a regular expression matching the paths it owns.
+// Patterns follow the gitignore rules: patterns with a leading or middle / are relative to the
+// repository root, other patterns match at any depth, and a matching directory owns its content.
+// A trailing /* matches only the files directly in a directory and a \ escapes the next character.
+func patternRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+	expression := &strings.Builder{}
+	expression.WriteString("^")
+	if !anchored {
+		expression.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expression.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expression.WriteString("[^/]")
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case directory:
+		expression.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "/**"):
+		expression.WriteString("$")
+	default:
+		expression.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expression.String())
+}
+
+// Resolver computes the owners of resources from the CODEOWNERS files of their repositories
+type Resolver struct {
+	registry registry.Interface
+
+	mutex sync.Mutex
+	// repositories are the CODEOWNERS rules per repository reference URL
+	repositories map[string]*repositoryCodeowners
+}
+
+type repositoryCodeowners struct {
+	once       sync.Once
+	codeowners *Codeowners
+	err        error
+}
+
+// NewResolver creates a Resolver reading the CODEOWNERS files through the registry
+func NewResolver(r registry.Interface) *Resolver {
+	return &Resolver{registry: r, repositories: map[string]*repositoryCodeowners{}}
+}
+
+// Owners returns the owners of the sources of a node in the order they are found
+func (r *Resolver) Owners(ctx context.Context, sources []string) ([]string, error) {
+	owners := []string{}
+	for _, source := range sources {
+		resourceURL, err := r.registry.ResourceURL(source)
+		if err != nil || resourceURL == nil {
+			continue
+		}
+		c, err := r.read(ctx, *resourceURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range c.Owners(resourceURL.GetResourcePath()) {
+			if !slices.Contains(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// read returns the CODEOWNERS rules of the repository of a resource, which are read once per repository reference
+func (r *Resolver) read(ctx context.Context, resourceURL repositoryhost.URL) (*Codeowners, error) {
+	refURL := resourceURL.ReferenceURL().String()
+	r.mutex.Lock()
+	repository, ok := r.repositories[refURL]
+	if !ok {
+		repository = &repositoryCodeowners{}
+		r.repositories[refURL] = repository
+	}
+	r.mutex.Unlock()
+	repository.once.Do(func() {
+		repository.codeowners, repository.err = r.readFile(ctx, resourceURL)
+	})
+	return repository.codeowners, repository.err
+}
+
+// readFile reads the CODEOWNERS rules of the repository of a resource, which are empty when there is no CODEOWNERS file
+func (r *Resolver) readFile(ctx context.Context, resourceURL repositoryhost.URL) (*Codeowners, error) {
+	c := &Codeowners{}
+	// GitHub looks for the CODEOWNERS file in these paths, in this order
+	for _, location := range []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"} {
+		fileURL, err := url.JoinPath(resourceURL.RepositoryURLString(), "blob", resourceURL.GetRef(), location)
+		if err != nil {
+			return nil, err
+		}
+		// files missing in the repository are not resolved
+		if _, err := r.registry.ResourceURL(fileURL); err != nil {
+			continue
+		}
+		content, err := r.registry.Read(ctx, fileURL)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s failed: %w", fileURL, err)
+		}
+		c = Parse(content)
+		break
+	}
+	return c, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package codeowners_test
+
+import (
+	"context"
+	"embed"
+	"sync"
+	"testing"
+
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/codeowners"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/registryfakes"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestCodeowners(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Codeowners Suite")
+}
+
+//go:embed tests/*
+var repository embed.FS
+
+var _ = Describe("Codeowners", func() {
+	DescribeTable("#Owners",
+		func(rules string, path string, expected []string) {
+			Expect(codeowners.Parse([]byte(rules)).Owners(path)).To(Equal(expected))
+		},
+		Entry("last matching rule wins", "* @all\n*.md @docs\n", "docs/a.md", []string{"@docs"}),
+		Entry("no matching rule", "*.go @dev\n", "docs/a.md", nil),
+		Entry("rule without owners", "* @all\ndocs/generated/\n", "docs/generated/api.md", []string{}),
+		Entry("unanchored directory at any depth", "apps/ @apps\n", "src/apps/a.md", []string{"@apps"}),
+		Entry("anchored directory", "/docs/ @docs\n", "src/docs/a.md", nil),
+		Entry("directory without trailing slash", "/docs @docs\n", "docs/guides/a.md", []string{"@docs"}),
+		Entry("files directly in a directory", "docs/* @docs\n", "docs/guides/a.md", nil),
+		Entry("files directly in a directory match", "docs/* @docs\n", "docs/a.md", []string{"@docs"}),
+		Entry("double asterisk", "docs/**/api.md @api\n", "docs/v1/beta/api.md", []string{"@api"}),
+		Entry("double asterisk in the same directory", "docs/**/api.md @api\n", "docs/api.md", []string{"@api"}),
+		Entry("comments", "# owners\n*.md @docs # markdown\n", "a.md", []string{"@docs"}),
+		Entry("escaped number sign", "\\#notes.md @notes # notes\n", "#notes.md", []string{"@notes"}),
+		Entry("escaped number sign in a path", "docs/\\#1.md @notes #notes\n", "docs/#1.md", []string{"@notes"}),
+	)
+
+	It("resolves the owners of sources from the CODEOWNERS file of their repository", func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repository, "https://github.com/gardener/docforge", "tests"))
+		resolver := codeowners.NewResolver(r)
+		owners, err := resolver.Owners(context.TODO(), []string{
+			"https://github.com/gardener/docforge/blob/master/docs/guide.md",
+			"https://github.com/gardener/docforge/blob/master/CODEOWNERS",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owners).To(Equal([]string{"@gardener/docs-team", "@one", "@gardener/docs"}))
+	})
+
+	It("reads the CODEOWNERS file of a repository once", func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repository, "https://github.com/gardener/docforge", "tests"))
+		fake := &registryfakes.FakeInterface{}
+		fake.ResourceURLCalls(r.ResourceURL)
+		fake.ReadCalls(r.Read)
+		resolver := codeowners.NewResolver(fake)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				owners, err := resolver.Owners(context.TODO(), []string{"https://github.com/gardener/docforge/blob/master/docs/guide.md"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(owners).To(Equal([]string{"@gardener/docs-team", "@one"}))
+			}()
+		}
+		wg.Wait()
+		Expect(fake.ReadCallCount()).To(Equal(1))
+	})
+})
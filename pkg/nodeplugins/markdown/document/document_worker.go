@@ -8,21 +8,27 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/codeowners"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/frontmatter"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/markdown"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkvalidator"
+	"github.com/gardener/docforge/pkg/postprocessors"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
 	"github.com/gardener/docforge/pkg/writers"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
 	"k8s.io/klog/v2"
 )
 
@@ -33,22 +39,32 @@ type Worker struct {
 	validator    linkvalidator.Interface
 
 	writer writers.Writer
+	// documents records the metadata of the documents for the post-processors, if it is not nil
+	documents postprocessors.DocumentRecorder
 
 	repositoryhosts    registry.Interface
+	gitInfo            *githubinfo.Reader
 	hugo               hugo.Hugo
 	skipLinkValidation bool
+	// maxIncludeDepth is the maximum depth of nested snippet includes, 0 means unlimited
+	maxIncludeDepth int
+	codeowners      *codeowners.Resolver
 }
 
 // NewDocumentWorker creates Worker objects
-func NewDocumentWorker(validator linkvalidator.Interface, linkResolver linkresolver.Interface, rh registry.Interface, hugo hugo.Hugo, writer writers.Writer, skipLinkValidation bool) *Worker {
+func NewDocumentWorker(validator linkvalidator.Interface, linkResolver linkresolver.Interface, rh registry.Interface, gitInfo *githubinfo.Reader, hugo hugo.Hugo, writer writers.Writer, documents postprocessors.DocumentRecorder, skipLinkValidation bool, maxIncludeDepth int) *Worker {
 	return &Worker{
 		markdown.New(),
 		linkResolver,
 		validator,
 		writer,
+		documents,
 		rh,
+		gitInfo,
 		hugo,
 		skipLinkValidation,
+		maxIncludeDepth,
+		codeowners.NewResolver(rh),
 	}
 }
 
@@ -69,7 +85,8 @@ func (d *Worker) ProcessNode(ctx context.Context, node *manifest.Node) error {
 		bytesBuff := bufPool.Get().(*bytes.Buffer)
 		defer bufPool.Put(bytesBuff)
 		bytesBuff.Reset()
-		if err := d.process(ctx, bytesBuff, node); err != nil {
+		document := &postprocessors.Document{}
+		if err := d.process(ctx, bytesBuff, node, document); err != nil {
 			return err
 		}
 		if bytesBuff.Len() == 0 {
@@ -77,6 +94,11 @@ func (d *Worker) ProcessNode(ctx context.Context, node *manifest.Node) error {
 			return nil
 		}
 		cnt = bytesBuff.Bytes()
+		// the documents are described to the post-processors without parsing the written files again
+		if d.documents != nil {
+			document.Frontmatter = maps.Clone(node.Frontmatter)
+			d.documents.RecordDocument(node, document)
+		}
 	}
 	if err := d.writer.Write(node.Name(), node.Path, cnt, node, d.hugo.IndexFileNames); err != nil {
 		return err
@@ -84,7 +106,8 @@ func (d *Worker) ProcessNode(ctx context.Context, node *manifest.Node) error {
 	return nil
 }
 
-func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node) error {
+// process renders the content of a node and describes it in document
+func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node, document *postprocessors.Document) error {
 	sources := []string{}
 	nodePath := n.NodePath()
 	if len(n.Source) > 0 {
@@ -110,7 +133,10 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 		}
 		dc := &docContent{docCnt: content, docURI: source}
 		if strings.HasSuffix(source, ".md") {
-			dc.docAst, err = markdown.Parse(d.markdown, content)
+			if dc.docCnt, err = d.transclude(ctx, content, source, []string{source}); err != nil {
+				return fmt.Errorf("fail to include snippets in %s from node %s: %w", source, nodePath, err)
+			}
+			dc.docAst, err = markdown.Parse(d.markdown, dc.docCnt)
 			if err != nil {
 				return fmt.Errorf("fail to parses %s from node %s: %w", source, nodePath, err)
 			}
@@ -127,13 +153,72 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 			}
 		}
 		frontmatter.MoveMultiSourceFrontmatterToTopDocument(docs)
+		// the manifest frontmatter overrides the document one, so its title keeps the heading
+		if _, ok := n.Frontmatter["title"]; d.hugo.Enabled && d.hugo.TitleFromHeading && !ok {
+			frontmatter.ExtractTitleFromHeading(firstDoc, fullContent[0].docCnt)
+		}
+		if d.hugo.Enabled && d.hugo.DescriptionFromContent {
+			frontmatter.ExtractDescription(firstDoc, fullContent[0].docCnt, d.hugo.DescriptionLength)
+		}
 		frontmatter.ComputeNodeTitle(firstDoc, n, d.hugo.IndexFileNames, d.hugo.Enabled)
+		if d.hugo.Enabled && d.hugo.GitInfoFrontmatter {
+			info, err := d.gitInfo.Read(ctx, n)
+			if err != nil {
+				return fmt.Errorf("fail to read git info of node %s: %w", nodePath, err)
+			}
+			frontmatter.SetGitInfo(firstDoc, info, d.hugo.GitInfoFrontmatterKeys)
+		}
+		if d.hugo.Enabled && d.hugo.OwnersFrontmatter {
+			owners, err := d.codeowners.Owners(ctx, sources)
+			if err != nil {
+				return fmt.Errorf("fail to read code owners of node %s: %w", nodePath, err)
+			}
+			frontmatter.SetOwners(firstDoc, owners)
+		}
 		frontmatter.MergeDocumentAndNodeFrontmatter(firstDoc, n)
+		if err := manifest.FrontmatterSchemaOf(n).Validate(n.Frontmatter); err != nil {
+			return fmt.Errorf("frontmatter of node %s from %s is invalid: %w", nodePath, strings.Join(sources, ", "), err)
+		}
+	}
+	options := manifest.MultiSourceOptions{}
+	if n.MultiSourceOptions != nil {
+		options = *n.MultiSourceOptions
 	}
-	for _, cnt := range fullContent {
-		lrt := linkResolverTask{*d, n, cnt.docURI}
+	recordText := d.documents != nil && d.documents.RecordsText()
+	texts := []string{}
+	anchors := newHeadingAnchors()
+	for i, cnt := range fullContent {
+		if i > 0 && options.Separator != "" {
+			if b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+				b.WriteString("\n")
+			}
+			b.WriteString("\n" + options.Separator + "\n\n")
+		}
+		resolveLink := recordLinks(document, linkResolverTask{*d, n, cnt.docURI, false})
+		resolveURL := recordLinks(document, linkResolverTask{*d, n, cnt.docURI, true})
 		if strings.HasSuffix(cnt.docURI, ".md") {
-			rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(lrt.resolveLink))
+			shift := 0
+			if i > 0 {
+				shift = options.ShiftHeadings
+			}
+			rendererOptions := []renderer.Option{
+				markdown.WithLinkResolver(resolveLink),
+				markdown.WithURLResolver(resolveURL),
+				markdown.WithAnchors(anchors.add(cnt.docAst, cnt.docCnt, shift)),
+			}
+			if shift != 0 {
+				rendererOptions = append(rendererOptions, markdown.WithHeadingShift(shift))
+			}
+			if d.hugo.Enabled && d.hugo.FrontmatterFormat != "" {
+				rendererOptions = append(rendererOptions, markdown.WithFrontmatterFormat(d.hugo.FrontmatterFormat))
+			}
+			if d.hugo.Enabled && d.hugo.AlertsEnabled {
+				rendererOptions = append(rendererOptions, markdown.WithAlerts(alertTemplates(d.hugo)))
+			}
+			if recordText {
+				texts = append(texts, frontmatter.ContentText(cnt.docAst, cnt.docCnt))
+			}
+			rnd := markdown.NewLinkModifierRenderer(rendererOptions...)
 			if err := rnd.Render(b, cnt.docCnt, cnt.docAst); err != nil {
 				return err
 			}
@@ -141,13 +226,113 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 			b.Write(cnt.docCnt)
 		}
 	}
+	document.Headings = anchors.headings
+	document.Text = strings.Join(texts, " ")
 	return nil
 }
 
+// alertTemplates returns the configured alert templates, falling back to the Docsy alert shortcodes
+func alertTemplates(hugoOptions hugo.Hugo) map[string]markdown.AlertTemplate {
+	configured := hugoOptions.AlertTemplates
+	if len(configured) == 0 {
+		configured = hugo.DefaultAlertTemplates()
+	}
+	templates := make(map[string]markdown.AlertTemplate, len(configured))
+	for alertType, template := range configured {
+		templates[strings.ToLower(alertType)] = markdown.AlertTemplate{Open: template.Open, Close: template.Close}
+	}
+	return templates
+}
+
+// headingAnchors computes unique heading anchors across concatenated documents
+// the same way Hugo and GitHub do, by suffixing repeated anchors with -1, -2, ...
+type headingAnchors struct {
+	counts map[string]int
+	// headings are the headings of all documents with their unique anchors
+	headings []postprocessors.Heading
+}
+
+func newHeadingAnchors() *headingAnchors {
+	return &headingAnchors{counts: map[string]int{}}
+}
+
+// add registers the headings of a document, whose levels are increased with shift, and returns
+// the mapping from the anchors unique in the document to the anchors unique in all documents
+func (h *headingAnchors) add(doc ast.Node, source []byte, shift int) map[string]string {
+	mapping := map[string]string{}
+	localCounts := map[string]int{}
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || node.Kind() != ast.KindHeading {
+			return ast.WalkContinue, nil
+		}
+		text := string(node.Text(source))
+		var global string
+		if id, ok := node.AttributeString("id"); ok {
+			// explicit {#id} anchors are kept and the generated anchors avoid them
+			global = string(id.([]byte))
+			localCounts[global]++
+			h.counts[global]++
+		} else {
+			anchor := headingAnchor(text)
+			local := uniqueAnchor(anchor, localCounts)
+			global = uniqueAnchor(anchor, h.counts)
+			if local != global {
+				mapping[local] = global
+			}
+		}
+		h.headings = append(h.headings, postprocessors.Heading{Level: min(node.(*ast.Heading).Level+shift, 6), Text: text, Anchor: global})
+		return ast.WalkSkipChildren, nil
+	})
+	return mapping
+}
+
+func uniqueAnchor(anchor string, counts map[string]int) string {
+	count := counts[anchor]
+	counts[anchor]++
+	if count == 0 {
+		return anchor
+	}
+	return fmt.Sprintf("%s-%d", anchor, count)
+}
+
+// headingAnchor converts heading text to an anchor, e.g. "Getting Started!" -> "getting-started"
+func headingAnchor(text string) string {
+	b := strings.Builder{}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// recordLinks appends the resolved non-embeddable links to the document
+func recordLinks(document *postprocessors.Document, lrt linkResolverTask) markdown.ResolveLink {
+	return func(dest string, isEmbeddable bool) (string, error) {
+		resolved, err := lrt.resolveLink(dest, isEmbeddable)
+		if err == nil && !isEmbeddable {
+			document.Links = append(document.Links, resolved)
+		}
+		return resolved, err
+	}
+}
+
 type linkResolverTask struct {
 	Worker
 	node   *manifest.Node
 	source string
+	// urls resolves the links to URLs, also when Hugo relref links are enabled
+	urls bool
+}
+
+func (d *linkResolverTask) resolveResourceLink(link string, source string) (string, error) {
+	if d.urls {
+		return d.linkresolver.ResolveResourceURL(link, d.node, source)
+	}
+	return d.linkresolver.ResolveResourceLink(link, d.node, source)
 }
 
 func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string, error) {
@@ -163,6 +348,10 @@ func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string,
 	if url.Scheme == "mailto" {
 		return dest, nil
 	}
+	// anchors of multisource documents refer to the concatenated document
+	if strings.HasPrefix(dest, "#") && len(d.node.MultiSource) > 0 {
+		return dest, nil
+	}
 	if isEmbeddable {
 		return d.resolveEmbededLink(dest, d.source)
 	}
@@ -176,7 +365,7 @@ func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string,
 			return dest, nil
 		}
 	}
-	return d.linkresolver.ResolveResourceLink(dest, d.node, d.source)
+	return d.resolveResourceLink(dest, d.source)
 }
 
 func (d *linkResolverTask) resolveEmbededLink(embeddedLink string, source string) (string, error) {
@@ -196,5 +385,5 @@ func (d *linkResolverTask) resolveEmbededLink(embeddedLink string, source string
 		return repositoryhost.RawURL(embeddedLink)
 	}
 	// resolve urls from referenced repositories
-	return d.linkresolver.ResolveResourceLink(resourceURL.String(), d.node, source)
+	return d.resolveResourceLink(resourceURL.String(), source)
 }
@@ -15,10 +15,14 @@ import (
 	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver/linkresolverfakes"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkvalidator/linkvalidatorfakes"
+	"github.com/gardener/docforge/pkg/postprocessors"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers"
 	"github.com/gardener/docforge/pkg/writers/writersfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -37,34 +41,44 @@ var _ = Describe("Document resolving", func() {
 		dw *document.Worker
 
 		w *writersfakes.FakeWriter
+		r registry.Interface
 	)
+
+	newWorker := func(lr linkresolver.Interface, hugo hugo.Hugo, writer writers.Writer, documents postprocessors.DocumentRecorder, maxIncludeDepth int) *document.Worker {
+		return document.NewDocumentWorker(&linkvalidatorfakes.FakeInterface{}, lr, r, githubinfo.NewReader(r), hugo, writer, documents, false, maxIncludeDepth)
+	}
+
+	newLinkResolver := func(hugo hugo.Hugo) linkresolver.Interface {
+		nodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/docs/manifest.yaml", r)
+		Expect(err).NotTo(HaveOccurred())
+		return linkresolver.New(nodes, r, hugo)
+	}
+
+	// newNode creates a file node with a source, or with multiple sources if more are given
+	newNode := func(file string, path string, sources ...string) *manifest.Node {
+		node := &manifest.Node{FileType: manifest.FileType{File: file}, Type: "file", Path: path}
+		if len(sources) == 1 {
+			node.Source = sources[0]
+		} else {
+			node.MultiSource = sources
+		}
+		return node
+	}
+
 	BeforeEach(func() {
-		registry := registry.NewRegistry(repositoryhost.NewLocalTest(manifests, "https://github.com/gardener/docforge", "tests"))
+		r = registry.NewRegistry(repositoryhost.NewLocalTest(manifests, "https://github.com/gardener/docforge", "tests"))
 		hugo := hugo.Hugo{
 			Enabled:        true,
 			BaseURL:        "baseURL",
 			IndexFileNames: []string{"readme.md", "readme", "read.me", "index.md", "index"},
 		}
-		vf := &linkvalidatorfakes.FakeInterface{}
-		nodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/docs/manifest.yaml", registry)
-		Expect(err).NotTo(HaveOccurred())
-
-		lr := linkresolver.New(nodes, registry, hugo)
-
 		w = &writersfakes.FakeWriter{}
-		dw = document.NewDocumentWorker(vf, lr, registry, hugo, w, false)
+		dw = newWorker(newLinkResolver(hugo), hugo, w, nil, 5)
 	})
 
 	Context("#ProcessNode", func() {
 		It("returns correct multisource content from md and html files", func() {
-			node := &manifest.Node{
-				FileType: manifest.FileType{
-					File:        "renamed-document.md",
-					MultiSource: []string{"https://github.com/gardener/docforge/blob/master/docs/target.md", "https://github.com/gardener/docforge/blob/master/docs/target2.md", "https://github.com/gardener/docforge/blob/master/docs/target3.html"},
-				},
-				Type: "file",
-				Path: "one",
-			}
+			node := newNode("renamed-document.md", "one", "https://github.com/gardener/docforge/blob/master/docs/target.md", "https://github.com/gardener/docforge/blob/master/docs/target2.md", "https://github.com/gardener/docforge/blob/master/docs/target3.html")
 			err := dw.ProcessNode(context.TODO(), node)
 			Expect(err).ToNot(HaveOccurred())
 			name, path, cnt, nodegot, _ := w.WriteArgsForCall(0)
@@ -83,14 +97,7 @@ var _ = Describe("Document resolving", func() {
 		})
 
 		It("returns correct single source content", func() {
-			node := &manifest.Node{
-				FileType: manifest.FileType{
-					File:   "renamed-document.md",
-					Source: "https://github.com/gardener/docforge/blob/master/docs/target.md",
-				},
-				Type: "file",
-				Path: "one",
-			}
+			node := newNode("renamed-document.md", "one", "https://github.com/gardener/docforge/blob/master/docs/target.md")
 			err := dw.ProcessNode(context.TODO(), node)
 			Expect(err).ToNot(HaveOccurred())
 			name, path, cnt, nodegot, _ := w.WriteArgsForCall(0)
@@ -103,5 +110,96 @@ var _ = Describe("Document resolving", func() {
 			Expect(node).To(Equal(nodegot))
 		})
 
+		It("expands include directives", func() {
+			Expect(dw.ProcessNode(context.TODO(), newNode("transclusion.md", "one", "https://github.com/gardener/docforge/blob/master/docs/transclusion.md"))).To(Succeed())
+			_, _, cnt, _, _ := w.WriteArgsForCall(0)
+			expected, err := manifests.ReadFile("tests/docs/expected_transclusion.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(cnt)).To(Equal(string(expected)))
+		})
+
+		It("limits the depth of nested includes", func() {
+			dw = newWorker(&linkresolverfakes.FakeInterface{}, hugo.Hugo{Enabled: true}, w, nil, 1)
+			err := dw.ProcessNode(context.TODO(), newNode("transclusion.md", "one", "https://github.com/gardener/docforge/blob/master/docs/transclusion.md"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("include depth exceeds the maximum of 1"))
+		})
+
+		It("fails on include cycles", func() {
+			err := dw.ProcessNode(context.TODO(), newNode("cycle.md", "one", "https://github.com/gardener/docforge/blob/master/docs/includes/cycle.md"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("include cycle detected"))
+		})
+
+		It("shifts headings and de-duplicates anchors of multisource documents", func() {
+			node := newNode("merged.md", "one", "https://github.com/gardener/docforge/blob/master/docs/multisource/overview.md", "https://github.com/gardener/docforge/blob/master/docs/multisource/details.md")
+			node.MultiSourceOptions = &manifest.MultiSourceOptions{ShiftHeadings: 1, Separator: "---"}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _, _ := w.WriteArgsForCall(0)
+			expected, err := manifests.ReadFile("tests/docs/expected_multisource.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(cnt)).To(Equal(string(expected)))
+		})
+
+		It("resolves raw HTML links to URLs in relref mode", func() {
+			hugo := hugo.Hugo{Enabled: true, BaseURL: "baseURL", RelrefLinks: true}
+			dw = newWorker(newLinkResolver(hugo), hugo, w, nil, 5)
+			Expect(dw.ProcessNode(context.TODO(), newNode("links.md", "html", "https://github.com/gardener/docforge/blob/master/docs/html/links.md"))).To(Succeed())
+			_, _, cnt, _, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(ContainSubstring(`[overview](<{{< relref "../multisource/overview.md" >}}>)`))
+			Expect(string(cnt)).To(ContainSubstring(`<a href="/baseURL/multisource/overview/#usage">`))
+		})
+
+		Context("recording the documents", func() {
+			var recorder *postprocessors.Recorder
+
+			BeforeEach(func() {
+				recorder = postprocessors.NewRecorder(w)
+				dw = newWorker(&linkresolverfakes.FakeInterface{}, hugo.Hugo{Enabled: true}, recorder, recorder, 5)
+			})
+
+			It("records the frontmatter, links and headings of the documents", func() {
+				node := newNode("merged.md", "one", "https://github.com/gardener/docforge/blob/master/docs/multisource/overview.md", "https://github.com/gardener/docforge/blob/master/docs/multisource/details.md")
+				node.MultiSourceOptions = &manifest.MultiSourceOptions{ShiftHeadings: 1}
+				Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+				outputs := recorder.Outputs()
+				Expect(outputs).To(HaveLen(1))
+				Expect(outputs[0].Path).To(Equal("one/merged.md"))
+				Expect(outputs[0].Document).To(Equal(&postprocessors.Document{
+					Frontmatter: map[string]interface{}{"title": "Merged"},
+					Links:       []string{"#usage", "#usage-1"},
+					Headings: []postprocessors.Heading{
+						{Level: 1, Text: "Overview", Anchor: "overview"},
+						{Level: 2, Text: "Usage", Anchor: "usage"},
+						{Level: 2, Text: "Details", Anchor: "details"},
+						{Level: 3, Text: "Usage", Anchor: "usage-1"},
+					},
+				}))
+			})
+
+			It("keeps explicit heading anchors", func() {
+				Expect(dw.ProcessNode(context.TODO(), newNode("merged.md", "one", "https://github.com/gardener/docforge/blob/master/docs/attributes/first.md", "https://github.com/gardener/docforge/blob/master/docs/attributes/second.md"))).To(Succeed())
+				_, _, cnt, _, _ := w.WriteArgsForCall(0)
+				Expect(string(cnt)).To(ContainSubstring("## Usage {#setup}\n"))
+				Expect(string(cnt)).To(ContainSubstring("See [usage](#usage-1).\n"))
+				document := recorder.Outputs()[0].Document
+				Expect(document.Links).To(Equal([]string{"#setup", "#usage-1"}))
+				Expect(document.Headings).To(Equal([]postprocessors.Heading{
+					{Level: 1, Text: "Install", Anchor: "install"},
+					{Level: 2, Text: "Usage", Anchor: "setup"},
+					{Level: 2, Text: "Usage", Anchor: "usage"},
+					{Level: 1, Text: "Upgrade", Anchor: "upgrade"},
+					{Level: 2, Text: "Usage", Anchor: "usage-1"},
+				}))
+			})
+
+			It("records the plain text of the documents if requested", func() {
+				recorder.RecordText = true
+				Expect(dw.ProcessNode(context.TODO(), newNode("merged.md", "one", "https://github.com/gardener/docforge/blob/master/docs/multisource/overview.md", "https://github.com/gardener/docforge/blob/master/docs/multisource/details.md"))).To(Succeed())
+				outputs := recorder.Outputs()
+				Expect(outputs).To(HaveLen(1))
+				Expect(outputs[0].Document.Text).To(Equal("Overview Usage See usage. Details Usage See usage."))
+			})
+		})
 	})
 })
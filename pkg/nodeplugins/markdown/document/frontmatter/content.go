@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontmatter
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// ExtractTitleFromHeading sets the document title to the text of its first heading if it is
+// a level 1 heading and removes the heading from the document. Existing titles are kept.
+func ExtractTitleFromHeading(doc *ast.Document, source []byte) {
+	docFrontmatter := doc.Meta()
+	if _, ok := docFrontmatter["title"]; ok {
+		return
+	}
+	for child := doc.FirstChild(); child != nil; child = child.NextSibling() {
+		heading, ok := child.(*ast.Heading)
+		if !ok {
+			continue
+		}
+		title := PlainText(heading, source)
+		if heading.Level != 1 || title == "" {
+			return
+		}
+		docFrontmatter["title"] = title
+		doc.RemoveChild(doc, heading)
+		return
+	}
+}
+
+// ExtractDescription sets the document description to the plain text of its first paragraph,
+// truncated to maxLength characters on a word boundary. Existing descriptions are kept.
+func ExtractDescription(doc *ast.Document, source []byte, maxLength int) {
+	docFrontmatter := doc.Meta()
+	if _, ok := docFrontmatter["description"]; ok {
+		return
+	}
+	for child := doc.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.Kind() != ast.KindParagraph {
+			continue
+		}
+		if description := truncate(PlainText(child, source), maxLength); description != "" {
+			docFrontmatter["description"] = description
+			return
+		}
+	}
+}
+
+// PlainText returns the text of a node stripped of markdown, images and raw HTML
+func PlainText(node ast.Node, source []byte) string {
+	b := strings.Builder{}
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Image, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// ContentText returns the plain text of a document for full-text search. The text of the blocks
+// is separated by spaces. Code blocks and raw HTML blocks are left out.
+func ContentText(doc ast.Node, source []byte) string {
+	texts := []string{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n.Type() != ast.TypeBlock {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindCodeBlock, ast.KindFencedCodeBlock, ast.KindHTMLBlock:
+			return ast.WalkSkipChildren, nil
+		}
+		// blocks with inline content, e.g. paragraphs, headings and table cells
+		if n.FirstChild() != nil && n.FirstChild().Type() == ast.TypeInline {
+			if text := PlainText(n, source); text != "" {
+				texts = append(texts, text)
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(texts, " ")
+}
+
+// truncate shortens text to maxLength characters on a word boundary, 0 means no limit
+func truncate(text string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	// leave room for the ellipsis
+	runes := []rune(text)
+	truncated := string(runes[:maxLength-1])
+	if i := strings.LastIndex(truncated, " "); i > 0 && runes[maxLength-1] != ' ' {
+		truncated = truncated[:i]
+	}
+	return strings.TrimRight(truncated, " ,.;:") + "…"
+}
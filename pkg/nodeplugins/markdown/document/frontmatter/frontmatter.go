@@ -78,24 +78,40 @@ func ComputeNodeTitle(nodeAst NodeMeta, node *manifest.Node, IndexFileNames []st
 	if docFrontmatter == nil {
 		docFrontmatter = map[string]interface{}{}
 	}
-	title := node.Name()
-	// index node with parent
-	if nodeIsIndexFile(node.Name(), IndexFileNames) && node.Parent() != nil && node.Parent().Path != "" {
-		title = node.Parent().Name()
-	} else if nodeIsIndexFile(node.Name(), IndexFileNames) && node.Parent() != nil && node.Parent().Path == "" {
-		// root index node
-		title = "Root"
-	}
-	title = strings.TrimSuffix(title, ".md")
-	title = strings.ReplaceAll(title, "_", " ")
-	title = strings.ReplaceAll(title, "-", " ")
-	title = cases.Title(language.English).String(title)
 	if _, ok := docFrontmatter["title"]; !ok {
-		docFrontmatter["title"] = title
+		docFrontmatter["title"] = NodeTitle(node, IndexFileNames)
 	}
 	nodeAst.SetMeta(docFrontmatter)
 }
 
+// Title returns the title set in the frontmatter of a node document or the title computed from the node name
+func Title(frontmatter map[string]interface{}, node *manifest.Node, IndexFileNames []string) string {
+	if title, ok := frontmatter["title"].(string); ok && title != "" {
+		return title
+	}
+	return NodeTitle(node, IndexFileNames)
+}
+
+// NodeTitle computes the title of a node from its name. Index files take the name of their parent
+// or Root at the top of the structure.
+func NodeTitle(node *manifest.Node, IndexFileNames []string) string {
+	name := node.Name()
+	if nodeIsIndexFile(name, IndexFileNames) && node.Parent() != nil {
+		name = "Root"
+		if node.Parent().Path != "" {
+			name = node.Parent().Name()
+		}
+	}
+	return Humanize(name)
+}
+
+// Humanize converts a file or directory name to a title
+func Humanize(name string) string {
+	name = strings.TrimSuffix(name, ".md")
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+	return cases.Title(language.English).String(name)
+}
+
 // Compares a node name to the configured list of index file
 // and a default name '_index.md' to determine if this node
 // is an index document node.
@@ -14,11 +14,14 @@ import (
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/frontmatter"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/frontmatter/frontmatterfakes"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/markdown"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/google/go-github/v43/github"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/yuin/goldmark/ast"
 )
 
 func TestJobs(t *testing.T) {
@@ -208,6 +211,135 @@ var _ = Describe("Document frontmatter", func() {
 			})
 
 		})
+		Context("#Title", func() {
+			It("prefers the frontmatter title", func() {
+				Expect(frontmatter.Title(map[string]interface{}{"title": "Set"}, nodes[5], indexFileNames)).To(Equal("Set"))
+			})
+			It("computes the title of documents without a title like ComputeNodeTitle", func() {
+				Expect(frontmatter.Title(nil, nodes[5], indexFileNames)).To(Equal("Parent Dir"))
+				Expect(frontmatter.Title(map[string]interface{}{"title": ""}, nodes[2], indexFileNames)).To(Equal("Root"))
+			})
+		})
+	})
+
+	Context("#ExtractTitleFromHeading", func() {
+		parse := func(content string) (*ast.Document, []byte) {
+			source := []byte(content)
+			doc, err := markdown.Parse(markdown.New(), source)
+			Expect(err).NotTo(HaveOccurred())
+			return doc.(*ast.Document), source
+		}
+
+		It("moves the first level 1 heading to the title", func() {
+			doc, source := parse("# The *Gardener* `docs`\n\nSome text\n")
+			frontmatter.ExtractTitleFromHeading(doc, source)
+			Expect(doc.Meta()["title"]).To(Equal("The Gardener docs"))
+			Expect(doc.FirstChild().Kind()).To(Equal(ast.KindParagraph))
+		})
+
+		It("keeps an existing title", func() {
+			doc, source := parse("---\ntitle: Existing\n---\n# Heading\n")
+			frontmatter.ExtractTitleFromHeading(doc, source)
+			Expect(doc.Meta()["title"]).To(Equal("Existing"))
+			Expect(doc.FirstChild().Kind()).To(Equal(ast.KindHeading))
+		})
+
+		It("ignores documents starting with a lower level heading", func() {
+			doc, source := parse("Intro\n\n## Heading\n\n# Other\n")
+			frontmatter.ExtractTitleFromHeading(doc, source)
+			Expect(doc.Meta()).NotTo(HaveKey("title"))
+			Expect(doc.ChildCount()).To(Equal(3))
+		})
+	})
+
+	Context("#ExtractDescription", func() {
+		parse := func(content string) (*ast.Document, []byte) {
+			source := []byte(content)
+			doc, err := markdown.Parse(markdown.New(), source)
+			Expect(err).NotTo(HaveOccurred())
+			return doc.(*ast.Document), source
+		}
+
+		It("uses the plain text of the first paragraph", func() {
+			doc, source := parse("# Title\n\n![logo](logo.png)\n\nRead the [**guide**](guide.md)\nbefore <b>starting</b>.\n\nSecond paragraph\n")
+			frontmatter.ExtractDescription(doc, source, 0)
+			Expect(doc.Meta()["description"]).To(Equal("Read the guide before starting."))
+		})
+
+		It("truncates the description on a word boundary", func() {
+			doc, source := parse("Gardener manages Kubernetes clusters, as a service.\n")
+			frontmatter.ExtractDescription(doc, source, 38)
+			Expect(doc.Meta()["description"]).To(Equal("Gardener manages Kubernetes clusters…"))
+		})
+
+		It("keeps an existing description", func() {
+			doc, source := parse("---\ndescription: Existing\n---\nSome text\n")
+			frontmatter.ExtractDescription(doc, source, 0)
+			Expect(doc.Meta()["description"]).To(Equal("Existing"))
+		})
 	})
 
+	Context("#ContentText", func() {
+		It("joins the plain text of the blocks without the code", func() {
+			source := []byte("# Title\n\nSome *text*\nwith [a link](guide.md).\n\n- one\n- two\n\n```bash\nls -l\n```\n\n| Name | Value |\n| --- | --- |\n| a | b |\n\n<div>html</div>\n")
+			doc, err := markdown.Parse(markdown.New(), source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frontmatter.ContentText(doc, source)).To(Equal("Title Some text with a link. one two Name Value a b"))
+		})
+	})
+
+	Context("#SetGitInfo", func() {
+		var info *repositoryhost.GitInfo
+
+		BeforeEach(func() {
+			info = &repositoryhost.GitInfo{
+				LastModifiedDate: github.String("2024-02-07 13:11:00"),
+				PublishDate:      github.String("2024-02-06 13:11:00"),
+				Author:           &github.User{Name: github.String("one"), Email: github.String("one@")},
+				Contributors:     []*github.User{{Login: github.String("two")}},
+			}
+		})
+
+		It("sets the git info under the Hugo keys", func() {
+			doc := ast.NewDocument()
+			frontmatter.SetGitInfo(doc, info, nil)
+			Expect(doc.Meta()).To(Equal(map[string]interface{}{
+				"lastmod":      "2024-02-07 13:11:00",
+				"publishDate":  "2024-02-06 13:11:00",
+				"author":       map[string]interface{}{"name": "one", "email": "one@"},
+				"contributors": []interface{}{map[string]interface{}{"login": "two"}},
+			}))
+		})
+
+		It("uses the configured keys and keeps the document frontmatter", func() {
+			doc := ast.NewDocument()
+			doc.SetMeta(map[string]interface{}{"modified": "2025-01-01"})
+			frontmatter.SetGitInfo(doc, info, map[string]string{"lastmod": "modified", "author": "owner"})
+			Expect(doc.Meta()).To(Equal(map[string]interface{}{
+				"modified":     "2025-01-01",
+				"publishDate":  "2024-02-06 13:11:00",
+				"owner":        map[string]interface{}{"name": "one", "email": "one@"},
+				"contributors": []interface{}{map[string]interface{}{"login": "two"}},
+			}))
+		})
+
+		It("skips the fields mapped to an empty key", func() {
+			doc := ast.NewDocument()
+			frontmatter.SetGitInfo(doc, info, map[string]string{"author": "", "contributors": ""})
+			Expect(doc.Meta()).To(Equal(map[string]interface{}{
+				"lastmod":     "2024-02-07 13:11:00",
+				"publishDate": "2024-02-06 13:11:00",
+			}))
+		})
+	})
+
+	Context("#SetOwners", func() {
+		It("sets the owners unless they are set", func() {
+			doc := ast.NewDocument()
+			frontmatter.SetOwners(doc, []string{"@gardener/docs"})
+			Expect(doc.Meta()).To(Equal(map[string]interface{}{"owners": []interface{}{"@gardener/docs"}}))
+			frontmatter.SetOwners(doc, []string{"@other"})
+			Expect(doc.Meta()["owners"]).To(Equal([]interface{}{"@gardener/docs"}))
+		})
+	})
 })
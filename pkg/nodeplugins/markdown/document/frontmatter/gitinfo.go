@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontmatter
+
+import (
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/google/go-github/v43/github"
+)
+
+// SetGitInfo sets the git info fields under their configured keys in the document frontmatter. The
+// configured keys override the default ones, keys already set in the document frontmatter are kept
+// and fields mapped to an empty key are skipped.
+func SetGitInfo(nodeAst NodeMeta, info *repositoryhost.GitInfo, configuredKeys map[string]string) {
+	if nodeAst == nil || info == nil {
+		return
+	}
+	keys := hugo.DefaultGitInfoFrontmatterKeys()
+	for field, key := range configuredKeys {
+		keys[field] = key
+	}
+	values := map[string]interface{}{}
+	if info.LastModifiedDate != nil {
+		values[hugo.GitInfoLastmod] = *info.LastModifiedDate
+	}
+	if info.PublishDate != nil {
+		values[hugo.GitInfoPublishDate] = *info.PublishDate
+	}
+	if info.Author != nil {
+		values[hugo.GitInfoAuthor] = user(info.Author)
+	}
+	if len(info.Contributors) > 0 {
+		contributors := []interface{}{}
+		for _, contributor := range info.Contributors {
+			contributors = append(contributors, user(contributor))
+		}
+		values[hugo.GitInfoContributors] = contributors
+	}
+	docFrontmatter := nodeAst.Meta()
+	if docFrontmatter == nil {
+		docFrontmatter = map[string]interface{}{}
+	}
+	for field, value := range values {
+		key := keys[field]
+		if _, ok := docFrontmatter[key]; key == "" || ok {
+			continue
+		}
+		docFrontmatter[key] = value
+	}
+	nodeAst.SetMeta(docFrontmatter)
+}
+
+// SetOwners sets the code owners as owners in the document frontmatter unless it is already set
+func SetOwners(nodeAst NodeMeta, owners []string) {
+	if nodeAst == nil || len(owners) == 0 {
+		return
+	}
+	docFrontmatter := nodeAst.Meta()
+	if docFrontmatter == nil {
+		docFrontmatter = map[string]interface{}{}
+	}
+	if _, ok := docFrontmatter["owners"]; ok {
+		return
+	}
+	values := make([]interface{}, 0, len(owners))
+	for _, owner := range owners {
+		values = append(values, owner)
+	}
+	docFrontmatter["owners"] = values
+	nodeAst.SetMeta(docFrontmatter)
+}
+
+// user returns the name, email and login of a git user
+func user(u *github.User) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if u.GetName() != "" {
+		fields["name"] = u.GetName()
+	}
+	if u.GetEmail() != "" {
+		fields["email"] = u.GetEmail()
+	}
+	if u.GetLogin() != "" {
+		fields["login"] = u.GetLogin()
+	}
+	return fields
+}
@@ -11,8 +11,10 @@ import (
 
 	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkvalidator"
+	"github.com/gardener/docforge/pkg/postprocessors"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/workers/taskqueue"
 	"github.com/gardener/docforge/pkg/writers"
@@ -31,9 +33,9 @@ type Processor interface {
 }
 
 // New creates a new Worker
-func New(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, validator linkvalidator.Interface, rhs registry.Interface, hugo hugo.Hugo, writer writers.Writer, skipLinkValidation bool) (Processor, taskqueue.QueueController, error) {
+func New(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, validator linkvalidator.Interface, rhs registry.Interface, gitInfo *githubinfo.Reader, hugo hugo.Hugo, writer writers.Writer, documents postprocessors.DocumentRecorder, skipLinkValidation bool, maxIncludeDepth int) (Processor, taskqueue.QueueController, error) {
 	lr := linkresolver.New(structure, rhs, hugo)
-	worker := NewDocumentWorker(validator, lr, rhs, hugo, writer, skipLinkValidation)
+	worker := NewDocumentWorker(validator, lr, rhs, gitInfo, hugo, writer, documents, skipLinkValidation, maxIncludeDepth)
 	queue, err := taskqueue.New("Document", workerCount, worker.execute, failFast, wg)
 	if err != nil {
 		return nil, nil, err
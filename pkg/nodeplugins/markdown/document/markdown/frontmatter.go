@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/pelletier/go-toml/v2"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterBlock locates the frontmatter at the start of source. It returns the frontmatter format
+// and content and the offset of the end of the block, or an empty format if there is no frontmatter.
+func frontmatterBlock(source []byte) (string, []byte, int) {
+	var (
+		format  string
+		content []byte
+		end     int
+	)
+	firstLine, _, _ := bytes.Cut(source, []byte("\n"))
+	switch {
+	case string(bytes.TrimSpace(firstLine)) == "---":
+		start := len(firstLine) + 1
+		closing := indexLine(source[start:], isYAMLSeparator)
+		if closing < 0 {
+			return "", nil, 0
+		}
+		closingLine, _, _ := bytes.Cut(source[start+closing:], []byte("\n"))
+		return manifest.FrontmatterFormatYAML, source[start : start+closing], start + closing + len(closingLine)
+	case string(bytes.TrimRight(firstLine, "\r")) == "+++":
+		start := len(firstLine) + 1
+		closing := indexLine(source[start:], func(line []byte) bool { return string(line) == "+++" })
+		if closing < 0 {
+			return "", nil, 0
+		}
+		format, content, end = manifest.FrontmatterFormatTOML, source[start:start+closing], start+closing+len("+++")
+	case bytes.HasPrefix(source, []byte("{")) && !bytes.HasPrefix(source, []byte("{{")):
+		decoder := json.NewDecoder(bytes.NewReader(source))
+		// content starting with { that is not a JSON object isn't frontmatter
+		if err := decoder.Decode(&map[string]interface{}{}); err != nil {
+			return "", nil, 0
+		}
+		end = int(decoder.InputOffset())
+		format, content = manifest.FrontmatterFormatJSON, source[:end]
+	default:
+		return "", nil, 0
+	}
+	// the frontmatter must end its line
+	if eol := bytes.IndexByte(source[end:], '\n'); eol >= 0 {
+		if len(bytes.TrimSpace(source[end:end+eol])) > 0 {
+			return "", nil, 0
+		}
+		return format, content, end + eol
+	}
+	if len(bytes.TrimSpace(source[end:])) > 0 {
+		return "", nil, 0
+	}
+	return format, content, len(source)
+}
+
+// decodeFrontmatter decodes frontmatter content. YAML is decoded the same way as goldmark-meta does.
+func decodeFrontmatter(content []byte, format string) (map[string]interface{}, error) {
+	fm := map[string]interface{}{}
+	switch format {
+	case manifest.FrontmatterFormatYAML:
+		if err := yamlv2.Unmarshal(content, &fm); err != nil {
+			return nil, err
+		}
+		return fm, nil
+	case manifest.FrontmatterFormatTOML:
+		if err := toml.Unmarshal(content, &fm); err != nil {
+			return nil, err
+		}
+	case manifest.FrontmatterFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fm); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported frontmatter format %s", format)
+	}
+	return manifest.NormalizeFrontmatter(fm).(map[string]interface{}), nil
+}
+
+// splitFrontmatter reads TOML or JSON frontmatter at the start of source. It returns the frontmatter
+// and the source with the frontmatter blanked out, which keeps the offsets of the content unchanged.
+// YAML frontmatter is left to goldmark-meta and nil frontmatter is returned for it.
+func splitFrontmatter(source []byte) (map[string]interface{}, []byte, error) {
+	format, content, end := frontmatterBlock(source)
+	if format != manifest.FrontmatterFormatTOML && format != manifest.FrontmatterFormatJSON {
+		return nil, source, nil
+	}
+	fm, err := decodeFrontmatter(content, format)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s frontmatter: %w", strings.ToUpper(format), err)
+	}
+	masked := bytes.Clone(source)
+	for i := 0; i < end; i++ {
+		if masked[i] != '\n' {
+			masked[i] = ' '
+		}
+	}
+	return fm, masked, nil
+}
+
+// marshalDocumentFrontmatter returns the frontmatter block of a document like MarshalFrontmatter, but keeps
+// the frontmatter found in source when it has the same format. Unchanged frontmatter is kept byte-identical.
+// Changed YAML frontmatter keeps the order, comments and formatting of the unchanged keys and the added
+// keys are appended in alphabetical order.
+func marshalDocumentFrontmatter(fm map[string]interface{}, source []byte, format string) ([]byte, error) {
+	if format == "" {
+		format = manifest.FrontmatterFormatYAML
+	}
+	sourceFormat, content, end := frontmatterBlock(source)
+	if sourceFormat != format {
+		return manifest.MarshalFrontmatter(fm, format)
+	}
+	original, err := decodeFrontmatter(content, sourceFormat)
+	if err != nil {
+		return manifest.MarshalFrontmatter(fm, format)
+	}
+	if reflect.DeepEqual(manifest.NormalizeFrontmatter(original), manifest.NormalizeFrontmatter(fm)) {
+		block := bytes.Clone(source[:end])
+		return append(block, '\n'), nil
+	}
+	if format != manifest.FrontmatterFormatYAML {
+		return manifest.MarshalFrontmatter(fm, format)
+	}
+	return mergeYAMLFrontmatter(fm, original, content)
+}
+
+// mergeYAMLFrontmatter updates the YAML frontmatter content with fm, keeping the nodes of unchanged values
+func mergeYAMLFrontmatter(fm map[string]interface{}, original map[string]interface{}, content []byte) ([]byte, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return manifest.MarshalFrontmatter(fm, manifest.FrontmatterFormatYAML)
+	}
+	mapping := doc.Content[0]
+	merged := []*yaml.Node{}
+	kept := map[string]bool{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		v, ok := fm[key.Value]
+		if !ok || kept[key.Value] {
+			continue
+		}
+		kept[key.Value] = true
+		if !reflect.DeepEqual(manifest.NormalizeFrontmatter(original[key.Value]), manifest.NormalizeFrontmatter(v)) {
+			encoded := &yaml.Node{}
+			if err := encoded.Encode(v); err != nil {
+				return nil, err
+			}
+			encoded.LineComment = value.LineComment
+			value = encoded
+		}
+		merged = append(merged, key, value)
+	}
+	added := []string{}
+	for k := range fm {
+		if !kept[k] {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+	for _, k := range added {
+		value := &yaml.Node{}
+		if err := value.Encode(fm[k]); err != nil {
+			return nil, err
+		}
+		merged = append(merged, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, value)
+	}
+	mapping.Content = merged
+	b := &bytes.Buffer{}
+	b.WriteString("---\n")
+	encoder := yaml.NewEncoder(b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	b.WriteString("---\n")
+	return b.Bytes(), nil
+}
+
+// isYAMLSeparator reports if a line closes YAML frontmatter the same way goldmark-meta does
+func isYAMLSeparator(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	return len(line) > 0 && len(bytes.Trim(line, "-")) == 0
+}
+
+// indexLine returns the offset of the first line matching or -1
+func indexLine(source []byte, matches func(line []byte) bool) int {
+	offset := 0
+	for _, l := range bytes.SplitAfter(source, []byte("\n")) {
+		if matches(bytes.TrimRight(l, "\r\n")) {
+			return offset
+		}
+		offset += len(l)
+	}
+	return -1
+}
@@ -10,16 +10,17 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/yuin/goldmark/ast"
 	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/text"
 	"github.com/yuin/goldmark/util"
 	"golang.org/x/net/html"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -33,6 +34,8 @@ var (
 	marker = regexp.MustCompile(`^\d{1,9}[.)] {1,4}`)
 	// defines a fence block line
 	fence = regexp.MustCompile("^ {0,3}```.*")
+	// defines a GitHub alert marker, e.g. [!NOTE]
+	alertMarker = regexp.MustCompile(`^\s*\[!([A-Za-z]+)\]\s*$`)
 	// defines a mermaid link
 	mermaidLink = regexp.MustCompile(`(^\s*click +[^"]+ +")([^"]+)(".*)`)
 	// GFM autolink extensions
@@ -68,6 +71,100 @@ func WithLinkResolver(linkResolver ResolveLink) renderer.Option {
 	return &withLinkResolver{linkResolver}
 }
 
+// URLResolver is an option name used in WithURLResolver.
+const optURLResolver renderer.OptionName = "URLResolver"
+
+type withURLResolver struct {
+	value ResolveLink
+}
+
+func (o *withURLResolver) SetConfig(c *renderer.Config) {
+	c.Options[optURLResolver] = o.value
+}
+
+// WithURLResolver is a functional option that sets the ResolveLink used for the links in raw HTML and
+// mermaid diagrams, which must resolve to URLs as Hugo doesn't expand shortcodes in them.
+// The ResolveLink set with WithLinkResolver is used if it is not set.
+func WithURLResolver(urlResolver ResolveLink) renderer.Option {
+	return &withURLResolver{urlResolver}
+}
+
+// HeadingShift is an option name used in WithHeadingShift.
+const optHeadingShift renderer.OptionName = "HeadingShift"
+
+type withHeadingShift struct {
+	value int
+}
+
+func (o *withHeadingShift) SetConfig(c *renderer.Config) {
+	c.Options[optHeadingShift] = o.value
+}
+
+// WithHeadingShift is a functional option that increases the level of the rendered headings with shift, up to level 6.
+func WithHeadingShift(shift int) renderer.Option {
+	return &withHeadingShift{shift}
+}
+
+// Anchors is an option name used in WithAnchors.
+const optAnchors renderer.OptionName = "Anchors"
+
+type withAnchors struct {
+	value map[string]string
+}
+
+func (o *withAnchors) SetConfig(c *renderer.Config) {
+	c.Options[optAnchors] = o.value
+}
+
+// WithAnchors is a functional option that rewrites in-document link anchors (#anchor) with the mapped ones
+// before the link destination is resolved.
+func WithAnchors(anchors map[string]string) renderer.Option {
+	return &withAnchors{anchors}
+}
+
+// AlertTemplate is the markup a GitHub alert blockquote is converted to, e.g.
+// {{% alert title="Note" color="info" %}} and {{% /alert %}}
+type AlertTemplate struct {
+	// Open is written before the alert content
+	Open string
+	// Close is written after the alert content
+	Close string
+}
+
+// Alerts is an option name used in WithAlerts.
+const optAlerts renderer.OptionName = "Alerts"
+
+type withAlerts struct {
+	value map[string]AlertTemplate
+}
+
+func (o *withAlerts) SetConfig(c *renderer.Config) {
+	c.Options[optAlerts] = o.value
+}
+
+// WithAlerts is a functional option that converts GitHub alert blockquotes (> [!NOTE]) to the templates
+// of the alert types. The alert types are the lowercase keys, e.g. note, tip, important, warning and caution.
+func WithAlerts(alerts map[string]AlertTemplate) renderer.Option {
+	return &withAlerts{alerts}
+}
+
+// FrontmatterFormat is an option name used in WithFrontmatterFormat.
+const optFrontmatterFormat renderer.OptionName = "FrontmatterFormat"
+
+type withFrontmatterFormat struct {
+	value string
+}
+
+func (o *withFrontmatterFormat) SetConfig(c *renderer.Config) {
+	c.Options[optFrontmatterFormat] = o.value
+}
+
+// WithFrontmatterFormat is a functional option that sets the format of the rendered frontmatter,
+// one of yaml (default), toml or json.
+func WithFrontmatterFormat(format string) renderer.Option {
+	return &withFrontmatterFormat{format}
+}
+
 // A linkModifierRenderer struct is an implementation of renderer.Renderer interface.
 type linkModifierRenderer struct {
 	config *renderer.Config
@@ -103,6 +200,24 @@ func (l *linkModifierRenderer) Render(w io.Writer, source []byte, node ast.Node)
 		markers:      make([]int, 0, 5),
 		emphasis:     make([]byte, 0, 5),
 	}
+	if alerts, ok := l.config.Options[optAlerts].(map[string]AlertTemplate); ok {
+		r.alerts = alerts
+	}
+	if shift, ok := l.config.Options[optHeadingShift].(int); ok {
+		r.headingShift = shift
+	}
+	if format, ok := l.config.Options[optFrontmatterFormat].(string); ok {
+		r.frontmatterFormat = format
+	}
+	r.urlResolver = r.linkResolver
+	if urlResolver, ok := l.config.Options[optURLResolver].(ResolveLink); ok {
+		r.urlResolver = urlResolver
+	}
+	if anchors, ok := l.config.Options[optAnchors].(map[string]string); ok && len(anchors) > 0 {
+		r.linkResolver = withMappedAnchors(r.linkResolver, anchors)
+		r.urlResolver = withMappedAnchors(r.urlResolver, anchors)
+	}
+	r.footnotes = footnoteRefs(node)
 	writer, ok := w.(*bytes.Buffer)
 	if ok {
 		r.writer = writer
@@ -146,8 +261,10 @@ func (l *linkModifierRenderer) Render(w io.Writer, source []byte, node ast.Node)
 			return r.renderImage(node, entering)
 		case ast.KindRawHTML:
 			return r.renderRawHTML(node, entering)
-		case ast.KindText, ast.KindString:
+		case ast.KindText:
 			return r.renderText(node, entering)
+		case ast.KindString:
+			return r.renderString(node, entering)
 		// GFM extension blocks
 		case extast.KindTable:
 			return r.renderTable(node, entering)
@@ -162,6 +279,22 @@ func (l *linkModifierRenderer) Render(w io.Writer, source []byte, node ast.Node)
 			return r.renderTaskCheckBox(node, entering)
 		case extast.KindStrikethrough:
 			return r.renderStrikethrough(node, entering)
+		// footnote extension
+		case extast.KindFootnoteLink:
+			return r.renderFootnoteLink(node, entering)
+		case extast.KindFootnoteBacklink:
+			return ast.WalkSkipChildren, nil
+		case extast.KindFootnoteList:
+			return r.renderFootnoteList(node, entering)
+		case extast.KindFootnote:
+			return r.renderFootnote(node, entering)
+		// definition list extension
+		case extast.KindDefinitionList:
+			return r.renderDefinitionList(node, entering)
+		case extast.KindDefinitionTerm:
+			return r.renderDefinitionTerm(node, entering)
+		case extast.KindDefinitionDescription:
+			return r.renderDefinitionDescription(node, entering)
 		default:
 			return ast.WalkContinue, nil
 		}
@@ -172,15 +305,30 @@ func (l *linkModifierRenderer) Render(w io.Writer, source []byte, node ast.Node)
 	return err
 }
 
+// withMappedAnchors returns a ResolveLink rewriting in-document link anchors with the mapped ones
+func withMappedAnchors(linkResolver ResolveLink, anchors map[string]string) ResolveLink {
+	return func(dest string, isEmbeddable bool) (string, error) {
+		if anchor, ok := anchors[strings.TrimPrefix(dest, "#")]; ok && strings.HasPrefix(dest, "#") {
+			dest = "#" + anchor
+		}
+		return linkResolver(dest, isEmbeddable)
+	}
+}
+
 // Renderer holds document source, buffer writer, info for indents and some nodes for rendering a markdown
 type Renderer struct {
-	source       []byte
-	writer       *bytes.Buffer
-	linkResolver ResolveLink
-	indents      []byte
-	markers      []int
-	emphasis     []byte
-	table        bool
+	source            []byte
+	writer            *bytes.Buffer
+	linkResolver      ResolveLink
+	urlResolver       ResolveLink
+	headingShift      int
+	frontmatterFormat string
+	alerts            map[string]AlertTemplate
+	footnotes         map[int][]byte
+	indents           []byte
+	markers           []int
+	emphasis          []byte
+	table             bool
 }
 
 // --------------------------- Node Renders
@@ -192,14 +340,12 @@ func (r *Renderer) renderDocument(node ast.Node, entering bool) (ast.WalkStatus,
 		// process frontmatter
 		fm := n.Meta()
 		if len(fm) > 0 {
-			_, _ = r.writer.Write([]byte("---\n"))
 			var cnt []byte
-			cnt, err = yaml.Marshal(fm)
+			cnt, err = marshalDocumentFrontmatter(fm, r.source, r.frontmatterFormat)
 			if err != nil {
 				return ast.WalkStop, err
 			}
 			_, _ = r.writer.Write(cnt)
-			_, _ = r.writer.Write([]byte("---\n"))
 			if n.HasChildren() {
 				r.newLine(false)
 			}
@@ -215,9 +361,75 @@ func (r *Renderer) renderDocument(node ast.Node, entering bool) (ast.WalkStatus,
 	return ast.WalkContinue, nil
 }
 
+// attribute marking blockquotes converted to alerts
+const alertAttribute = "docforge-alert"
+
+// alert returns the template of a GitHub alert blockquote. When entering the blockquote,
+// the [!TYPE] marker line is removed from its first paragraph.
+func (r *Renderer) alert(n ast.Node, entering bool) (AlertTemplate, bool) {
+	if len(r.alerts) == 0 {
+		return AlertTemplate{}, false
+	}
+	if !entering {
+		alertType, ok := n.AttributeString(alertAttribute)
+		if !ok {
+			return AlertTemplate{}, false
+		}
+		return r.alerts[alertType.(string)], true
+	}
+	p, ok := n.FirstChild().(*ast.Paragraph)
+	if !ok || p.Lines().Len() == 0 {
+		return AlertTemplate{}, false
+	}
+	markerLine := p.Lines().At(0)
+	match := alertMarker.FindSubmatch(markerLine.Value(r.source))
+	if match == nil {
+		return AlertTemplate{}, false
+	}
+	alertType := strings.ToLower(string(match[1]))
+	alert, ok := r.alerts[alertType]
+	if !ok {
+		return AlertTemplate{}, false
+	}
+	n.SetAttributeString(alertAttribute, alertType)
+	for c := p.FirstChild(); c != nil; {
+		t, ok := c.(*ast.Text)
+		if !ok || t.Segment.Start >= markerLine.Stop {
+			break
+		}
+		next := c.NextSibling()
+		p.RemoveChild(p, c)
+		c = next
+	}
+	if !p.HasChildren() {
+		n.RemoveChild(n, p)
+	}
+	// blank previous lines are not calculated in blockquote scope, the blocks of the alert are separated explicitly
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.PreviousSibling() != nil {
+			c.SetBlankPreviousLines(true)
+		}
+	}
+	return alert, true
+}
+
 // commonmark container blocks
 
 func (r *Renderer) renderBlockquote(n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if alert, ok := r.alert(n, entering); ok {
+		if entering {
+			r.blockSeparator(n)
+			_, _ = r.writer.WriteString(alert.Open)
+			if n.HasChildren() {
+				r.newLine(true)
+			}
+		} else {
+			r.newLine(true)
+			_, _ = r.writer.WriteString(alert.Close)
+			breakBlockquoteLazyContinuation(n.NextSibling())
+		}
+		return ast.WalkContinue, nil
+	}
 	if entering {
 		r.blockSeparator(n)
 		// no laziness - block new lines will always start with '>'
@@ -256,20 +468,22 @@ func (r *Renderer) renderListItem(node ast.Node, entering bool) (ast.WalkStatus,
 
 func (r *Renderer) renderHeading(node ast.Node, entering bool) (ast.WalkStatus, error) {
 	n := node.(*ast.Heading)
+	level := min(n.Level+r.headingShift, 6)
 	atx := true // defaults to ATX headings
-	if n.Lines().Len() > 1 && n.Level <= 2 {
+	if n.Lines().Len() > 1 && level <= 2 {
 		atx = false // multiline heading -> use Setext headings
 	}
 	if entering {
 		r.blockSeparator(n)
 		if atx {
-			_, _ = r.writer.Write(bytes.Repeat([]byte{'#'}, n.Level))
+			_, _ = r.writer.Write(bytes.Repeat([]byte{'#'}, level))
 			_ = r.writer.WriteByte(' ')
 		}
 	} else {
+		r.writeAttributes(n)
 		if !atx {
 			r.newLine(true)
-			if n.Level == 1 {
+			if level == 1 {
 				_, _ = r.writer.Write([]byte{'=', '=', '='})
 			} else {
 				_, _ = r.writer.Write([]byte{'-', '-', '-'})
@@ -599,6 +813,15 @@ func (r *Renderer) renderText(node ast.Node, entering bool) (ast.WalkStatus, err
 	return ast.WalkSkipChildren, nil
 }
 
+func (r *Renderer) renderString(node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		n := node.(*ast.String)
+		// typographer substitutions are code strings with HTML entities that are kept as they are
+		_, _ = r.writer.Write(n.Value)
+	}
+	return ast.WalkSkipChildren, nil
+}
+
 // GFM extension blocks
 
 func (r *Renderer) renderTable(n ast.Node, entering bool) (ast.WalkStatus, error) {
@@ -688,6 +911,155 @@ func (r *Renderer) renderStrikethrough(_ ast.Node, entering bool) (ast.WalkStatu
 
 // ---------------------------
 
+// footnote extension
+
+func (r *Renderer) renderFootnoteLink(node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		n := node.(*extast.FootnoteLink)
+		_, _ = r.writer.Write([]byte("[^"))
+		_, _ = r.writer.Write(r.footnoteRef(n.Index))
+		_ = r.writer.WriteByte(']')
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *Renderer) renderFootnoteList(n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		// footnotes are moved to the end of the document
+		n.SetBlankPreviousLines(true)
+		r.blockSeparator(n)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *Renderer) renderFootnote(node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*extast.Footnote)
+	if entering {
+		if n.PreviousSibling() != nil {
+			n.SetBlankPreviousLines(true)
+		}
+		r.blockSeparator(n)
+		_, _ = r.writer.Write([]byte("[^"))
+		_, _ = r.writer.Write(n.Ref)
+		_, _ = r.writer.Write([]byte("]: "))
+		r.markers = append(r.markers, 4)
+		r.indents = append(r.indents, bytes.Repeat([]byte{' '}, 4)...)
+	} else {
+		r.indents = r.indents[:len(r.indents)-r.markers[len(r.markers)-1]]
+		r.markers = r.markers[:len(r.markers)-1]
+	}
+	return ast.WalkContinue, nil
+}
+
+// returns the footnote references by footnote index
+func footnoteRefs(node ast.Node) map[int][]byte {
+	refs := map[int][]byte{}
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && n.Kind() == extast.KindFootnote {
+			footnote := n.(*extast.Footnote)
+			refs[footnote.Index] = footnote.Ref
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return refs
+}
+
+func (r *Renderer) footnoteRef(index int) []byte {
+	if ref, ok := r.footnotes[index]; ok {
+		return ref
+	}
+	return []byte(strconv.Itoa(index))
+}
+
+// definition list extension
+
+func (r *Renderer) renderDefinitionList(n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		r.blockSeparator(n)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *Renderer) renderDefinitionTerm(n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		if n.PreviousSibling() != nil && n.PreviousSibling().Kind() == extast.KindDefinitionDescription {
+			n.SetBlankPreviousLines(true)
+		}
+		r.blockSeparator(n)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *Renderer) renderDefinitionDescription(node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*extast.DefinitionDescription)
+	if entering {
+		r.blockSeparator(n)
+		_, _ = r.writer.Write([]byte(": "))
+		r.markers = append(r.markers, 2)
+		r.indents = append(r.indents, ' ', ' ')
+	} else {
+		r.indents = r.indents[:len(r.indents)-r.markers[len(r.markers)-1]]
+		r.markers = r.markers[:len(r.markers)-1]
+	}
+	return ast.WalkContinue, nil
+}
+
+// attributes
+
+// writes the node attributes in the {#id .class key="value"} form
+func (r *Renderer) writeAttributes(n ast.Node) {
+	attributes := []string{}
+	for _, attribute := range n.Attributes() {
+		name := string(attribute.Name)
+		if name == alertAttribute {
+			continue
+		}
+		value, isString := attribute.Value.([]byte)
+		switch {
+		case name == "id" && isString:
+			attributes = append(attributes, "#"+string(value))
+		case name == "class" && isString:
+			for _, class := range strings.Fields(string(value)) {
+				attributes = append(attributes, "."+class)
+			}
+		default:
+			attributes = append(attributes, name+"="+attributeValue(attribute.Value))
+		}
+	}
+	if len(attributes) > 0 {
+		_, _ = r.writer.WriteString(" {" + strings.Join(attributes, " ") + "}")
+	}
+}
+
+// attributeValue writes the attribute values of all types the goldmark parser produces
+func attributeValue(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.Quote(string(v))
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, attributeValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case parser.Attributes:
+		items := make([]string, 0, len(v))
+		for _, attribute := range v {
+			items = append(items, string(attribute.Name)+"="+attributeValue(attribute.Value))
+		}
+		return "{" + strings.Join(items, " ") + "}"
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
+
 func (r *Renderer) newLine(indents bool) {
 	_ = r.writer.WriteByte('\n')
 	if indents {
@@ -774,7 +1146,7 @@ func (r *Renderer) modifyHTMLTags(source []byte, target io.Writer) (bool, error)
 		if "a" == t.Data {
 			for i, a := range t.Attr {
 				if a.Key == "href" {
-					dest, err := r.linkResolver(a.Val, false)
+					dest, err := r.urlResolver(a.Val, false)
 					if err != nil {
 						return modified, err
 					}
@@ -788,7 +1160,7 @@ func (r *Renderer) modifyHTMLTags(source []byte, target io.Writer) (bool, error)
 		} else if "img" == t.Data {
 			for i, a := range t.Attr {
 				if a.Key == "src" {
-					dest, err := r.linkResolver(a.Val, true)
+					dest, err := r.urlResolver(a.Val, true)
 					if err != nil {
 						return modified, err
 					}
@@ -818,7 +1190,7 @@ func (r *Renderer) modifyMermaid(source []byte, target *bytes.Buffer) (bool, err
 			if "." == strings.TrimSpace(dest) {
 				dest = "."
 			} else {
-				dest, err = r.linkResolver(dest, false)
+				dest, err = r.urlResolver(dest, false)
 				if err != nil {
 					return modified, err
 				}
@@ -11,7 +11,9 @@ import (
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/markdown"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer"
 )
 
@@ -230,6 +232,119 @@ var _ = Describe("Links modifier", func() {
 			})
 		})
 	})
+	When("Render markdown with extensions", func() {
+		Context("footnotes", func() {
+			BeforeEach(func() {
+				lr.dst = "https://fake.com"
+				md = "Text with a note[^1] and another[^note].\n\n[^1]: First [link](./foo.md).\n\n[^note]: Second note\n    with two lines.\n"
+				exp = "Text with a note[^1] and another[^note].\n\n[^1]: First [link](https://fake.com).\n\n[^note]: Second note\n    with two lines.\n"
+			})
+			It("renders the footnotes", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("definition lists", func() {
+			BeforeEach(func() {
+				md = "Term\n: Definition\n\nOther term\n: First definition\n: Second definition\n  continued.\n"
+				exp = md
+			})
+			It("renders the definition lists", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("attributes", func() {
+			BeforeEach(func() {
+				md = "## Heading {#custom-id .red .big}\n\nText\n"
+				exp = md
+			})
+			It("renders the heading attributes", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("attributes of other types", func() {
+			BeforeEach(func() {
+				md = "## Heading {#custom-id level=2 ratio=0.5 draft=true tags=[\"a\", 1]}\n\nText\n"
+				exp = md
+			})
+			It("renders numbers, booleans and lists", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("typographer", func() {
+			It("renders the typographic substitutions", func() {
+				md = "\"Quoted\" -- text...\n"
+				doc, err = markdown.Parse(goldmark.New(goldmark.WithExtensions(extension.Typographer)), []byte(md))
+				Expect(err).NotTo(HaveOccurred())
+				buf = &bytes.Buffer{}
+				err = rnd.Render(buf, []byte(md), doc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal("&ldquo;Quoted&rdquo; &ndash; text&hellip;\n"))
+			})
+		})
+	})
+	When("Render markdown with heading shift", func() {
+		BeforeEach(func() {
+			rnd = markdown.NewLinkModifierRenderer(markdown.WithHeadingShift(1))
+			md = "# Title\n\nSetext\n---\n\n###### Deepest\n"
+			exp = "## Title\n\n### Setext\n\n###### Deepest\n"
+		})
+		It("shifts the heading levels", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(exp))
+		})
+	})
+	When("Render markdown with alerts", func() {
+		BeforeEach(func() {
+			rnd = markdown.NewLinkModifierRenderer(markdown.WithAlerts(map[string]markdown.AlertTemplate{
+				"note": {Open: `{{% alert title="Note" color="info" %}}`, Close: "{{% /alert %}}"},
+			}))
+		})
+		Context("alert with configured template", func() {
+			BeforeEach(func() {
+				md = "Text\n\n> [!NOTE]\n> Useful information.\n>\n> More **details**.\n\nAfter\n"
+				exp = "Text\n\n{{% alert title=\"Note\" color=\"info\" %}}\nUseful information.\n\nMore **details**.\n{{% /alert %}}\n\nAfter\n"
+			})
+			It("converts the blockquote", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("alert without template", func() {
+			BeforeEach(func() {
+				md = "> [!WARNING]\n> Careful.\n"
+				exp = md
+			})
+			It("keeps the blockquote", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+		Context("plain blockquote", func() {
+			BeforeEach(func() {
+				md = "> Quote\n"
+				exp = md
+			})
+			It("keeps the blockquote", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal(exp))
+			})
+		})
+	})
+	When("Render markdown with anchors", func() {
+		BeforeEach(func() {
+			rnd = markdown.NewLinkModifierRenderer(markdown.WithAnchors(map[string]string{"usage": "usage-1"}))
+			md = "[usage](#usage) [other](#other) [page](./usage.md)\n"
+			exp = "[usage](#usage-1) [other](#other) [page](./usage.md)\n"
+		})
+		It("rewrites the mapped anchors", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(exp))
+		})
+	})
 })
 
 type linkResolver struct {
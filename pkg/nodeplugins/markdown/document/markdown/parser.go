@@ -19,23 +19,32 @@ import (
 func New() goldmark.Markdown {
 	// extends Linkify regex by excluding trailing whitespaces and punctuations `[^\s<?!.,:*_~]`
 	urlRgx := regexp.MustCompile(`^(?:http|https|ftp)://[-a-zA-Z0-9@:%._\+~#=]{1,256}\.[a-z]+(?::\d+)?(?:[/#?][-a-zA-Z0-9@:%_+.~#$!?&/=\(\);,'">\^{}\[\]` + "`" + `]*)?[^\s<?!.,:*_~]`)
-	// parser extension for GitHub Flavored Markdown & Frontmatter support
+	// parser extension for GitHub Flavored Markdown, Frontmatter, footnotes & definition lists support
 	extensions := []goldmark.Extender{
 		extension.GFM,
 		meta.Meta,
+		extension.Footnote,
+		extension.DefinitionList,
 	}
-	return goldmark.New(goldmark.WithExtensions(extensions...), goldmark.WithParserOptions(extension.WithLinkifyURLRegexp(urlRgx)))
+	return goldmark.New(goldmark.WithExtensions(extensions...), goldmark.WithParserOptions(extension.WithLinkifyURLRegexp(urlRgx), parser.WithAttribute()))
 }
 
-// Parse markdown content and returns AST node or error
+// Parse markdown content and returns AST node or error. YAML, TOML and JSON frontmatter is set as document meta.
 func Parse(markdown goldmark.Markdown, source []byte) (ast.Node, error) {
-	reader := text.NewReader(source)
+	fm, masked, err := splitFrontmatter(source)
+	if err != nil {
+		return nil, err
+	}
+	reader := text.NewReader(masked)
 	context := parser.NewContext()
 	doc := markdown.Parser().Parse(reader, parser.WithContext(context))
 	fmb, err := meta.TryGet(context)
 	if err != nil {
 		return nil, err
 	}
+	if fm != nil {
+		fmb = fm
+	}
 	if doc.Kind() == ast.KindDocument {
 		doc.(*ast.Document).SetMeta(fmb)
 	}
@@ -7,6 +7,7 @@ package markdown_test
 import (
 	"bytes"
 
+	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/markdown"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -42,6 +43,48 @@ var _ = Describe("Parser", func() {
 				Expect(err.Error()).To(ContainSubstring("a = b"))
 			})
 		})
+		Context("TOML frontmatter", func() {
+			BeforeEach(func() {
+				md = "+++\ntitle = \"test\"\nweight = 10\ndate = 2024-05-01\ntags = [\"a\", \"b\"]\n+++\n\n## Heading level 2\n"
+			})
+			It("parses the frontmatter", func() {
+				Expect(err).NotTo(HaveOccurred())
+				d := doc.(*ast.Document)
+				Expect(d.Meta()).To(Equal(map[string]interface{}{"title": "test", "weight": 10, "date": "2024-05-01", "tags": []interface{}{"a", "b"}}))
+				Expect(d.FirstChild().Kind()).To(Equal(ast.KindHeading))
+				Expect(d.ChildCount()).To(Equal(1))
+			})
+		})
+		Context("TOML frontmatter invalid", func() {
+			BeforeEach(func() {
+				md = "+++\ntitle: test\n+++\n\n## Heading level 2\n"
+			})
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid TOML frontmatter"))
+			})
+		})
+		Context("JSON frontmatter", func() {
+			BeforeEach(func() {
+				md = "{\n  \"title\": \"test\",\n  \"weight\": 10,\n  \"params\": {\"ratio\": 1.5}\n}\n\n## Heading level 2\n"
+			})
+			It("parses the frontmatter", func() {
+				Expect(err).NotTo(HaveOccurred())
+				d := doc.(*ast.Document)
+				Expect(d.Meta()).To(Equal(map[string]interface{}{"title": "test", "weight": 10, "params": map[string]interface{}{"ratio": 1.5}}))
+				Expect(d.ChildCount()).To(Equal(1))
+			})
+		})
+		Context("content starting with a shortcode", func() {
+			BeforeEach(func() {
+				md = "{{% pageinfo %}}\nText\n{{% /pageinfo %}}\n"
+			})
+			It("is not parsed as frontmatter", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(doc.(*ast.Document).Meta()).To(BeEmpty())
+				Expect(doc.ChildCount()).To(Equal(1))
+			})
+		})
 		Context("add frontmatter", func() {
 			var (
 				buf *bytes.Buffer
@@ -65,5 +108,60 @@ var _ = Describe("Parser", func() {
 				Expect(buf.String()).To(Equal("---\ntitle: test\n---\n\n## Heading level 2\n\nI really like using Markdown.\n"))
 			})
 		})
+		Context("existing frontmatter", func() {
+			var (
+				buf *bytes.Buffer
+				d   *ast.Document
+			)
+			BeforeEach(func() {
+				md = "---\n# page metadata\ntitle:   \"Test\"\nweight: 10 # sort order\ntags: [a, b]\ndraft: false\n---\n\nText\n"
+			})
+			JustBeforeEach(func() {
+				Expect(err).NotTo(HaveOccurred())
+				d = doc.(*ast.Document)
+				buf = &bytes.Buffer{}
+			})
+			It("keeps untouched frontmatter byte-identical", func() {
+				Expect(markdown.NewLinkModifierRenderer().Render(buf, []byte(md), d)).To(Succeed())
+				Expect(buf.String()).To(Equal(md))
+			})
+			It("keeps the order and comments of changed frontmatter", func() {
+				d.Meta()["weight"] = 20
+				d.Meta()["aliases"] = []interface{}{"/old/"}
+				d.Meta()["github_repo"] = "https://github.com/gardener/docforge"
+				delete(d.Meta(), "draft")
+				Expect(markdown.NewLinkModifierRenderer().Render(buf, []byte(md), d)).To(Succeed())
+				Expect(buf.String()).To(Equal("---\n# page metadata\ntitle: \"Test\"\nweight: 20 # sort order\ntags: [a, b]\naliases:\n  - /old/\ngithub_repo: https://github.com/gardener/docforge\n---\n\nText\n"))
+			})
+		})
+		Context("frontmatter format", func() {
+			It("renders TOML frontmatter", func() {
+				md = "+++\ntitle = \"test\"\n+++\n\nText\n"
+				doc, err = markdown.Parse(markdown.New(), []byte(md))
+				Expect(err).NotTo(HaveOccurred())
+				buf := &bytes.Buffer{}
+				rnd := markdown.NewLinkModifierRenderer(markdown.WithFrontmatterFormat(manifest.FrontmatterFormatTOML))
+				Expect(rnd.Render(buf, []byte(md), doc)).To(Succeed())
+				Expect(buf.String()).To(Equal(md))
+			})
+			It("converts TOML frontmatter to the configured format", func() {
+				md = "+++\ntitle = \"test\"\n+++\n\nText\n"
+				doc, err = markdown.Parse(markdown.New(), []byte(md))
+				Expect(err).NotTo(HaveOccurred())
+				buf := &bytes.Buffer{}
+				rnd := markdown.NewLinkModifierRenderer()
+				Expect(rnd.Render(buf, []byte(md), doc)).To(Succeed())
+				Expect(buf.String()).To(Equal("---\ntitle: test\n---\n\nText\n"))
+			})
+			It("renders JSON frontmatter", func() {
+				md = "---\ntitle: test\nweight: 1\n---\n\nText\n"
+				doc, err = markdown.Parse(markdown.New(), []byte(md))
+				Expect(err).NotTo(HaveOccurred())
+				buf := &bytes.Buffer{}
+				rnd := markdown.NewLinkModifierRenderer(markdown.WithFrontmatterFormat(manifest.FrontmatterFormatJSON))
+				Expect(rnd.Render(buf, []byte(md), doc)).To(Succeed())
+				Expect(buf.String()).To(Equal("{\n  \"title\": \"test\",\n  \"weight\": 1\n}\n\nText\n"))
+			})
+		})
 	})
 })
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package document
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/markdown"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	// matches a line with <!-- docforge:include src="../api/example.yaml" lines="10-30" lang="yaml" -->
+	includeDirective = regexp.MustCompile(`^\s*<!--\s*docforge:include\s+(.*?)\s*-->\s*$`)
+	// matches the key="value" attributes of the include directive
+	includeAttribute = regexp.MustCompile(`([a-z]+)="([^"]*)"`)
+	// matches code fence openings and closings
+	codeFence = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})")
+)
+
+// include is a parsed docforge:include directive
+type include struct {
+	src    string
+	lines  string
+	region string
+	lang   string
+}
+
+func parseInclude(attributes string) (*include, error) {
+	inc := &include{}
+	for _, attribute := range includeAttribute.FindAllStringSubmatch(attributes, -1) {
+		switch attribute[1] {
+		case "src":
+			inc.src = attribute[2]
+		case "lines":
+			inc.lines = attribute[2]
+		case "region":
+			inc.region = attribute[2]
+		case "lang":
+			inc.lang = attribute[2]
+		default:
+			return nil, fmt.Errorf("unknown include attribute %s", attribute[1])
+		}
+	}
+	if inc.src == "" {
+		return nil, fmt.Errorf("include directive %q has no src", attributes)
+	}
+	return inc, nil
+}
+
+// transclude expands the docforge:include directives outside code blocks of a markdown content from source.
+// includeChain contains the sources including the content and is used to detect cycles.
+func (d *Worker) transclude(ctx context.Context, content []byte, source string, includeChain []string) ([]byte, error) {
+	if !bytes.Contains(content, []byte("docforge:include")) {
+		return content, nil
+	}
+	out := &bytes.Buffer{}
+	fence := ""
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		if f := codeFence.FindStringSubmatch(line); f != nil {
+			if fence == "" {
+				fence = f[1]
+			} else if strings.HasPrefix(f[1], fence) {
+				fence = ""
+			}
+		}
+		directive := includeDirective.FindStringSubmatch(line)
+		if fence != "" || directive == nil {
+			out.WriteString(line)
+			continue
+		}
+		inc, err := parseInclude(directive[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid include directive in %s: %w", source, err)
+		}
+		expanded, err := d.expandInclude(ctx, inc, source, includeChain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to include %s in %s: %w", inc.src, source, err)
+		}
+		out.Write(expanded)
+		if !bytes.HasSuffix(expanded, []byte("\n")) {
+			out.WriteString("\n")
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func (d *Worker) expandInclude(ctx context.Context, inc *include, source string, includeChain []string) ([]byte, error) {
+	includedURL := inc.src
+	if repositoryhost.IsRelative(includedURL) {
+		var err error
+		if includedURL, err = d.repositoryhosts.ResolveRelativeLink(source, includedURL); err != nil {
+			return nil, err
+		}
+	}
+	chain := append(slices.Clone(includeChain), includedURL)
+	if slices.Contains(includeChain, includedURL) {
+		return nil, fmt.Errorf("include cycle detected: %s", strings.Join(chain, " -> "))
+	}
+	if d.maxIncludeDepth > 0 && len(includeChain) > d.maxIncludeDepth {
+		return nil, fmt.Errorf("include depth exceeds the maximum of %d: %s", d.maxIncludeDepth, strings.Join(chain, " -> "))
+	}
+	content, err := d.repositoryhosts.Read(ctx, includedURL)
+	if err != nil {
+		return nil, err
+	}
+	if inc.region != "" {
+		if content, err = selectRegion(content, inc.region); err != nil {
+			return nil, err
+		}
+	}
+	if inc.lines != "" {
+		if content, err = selectLines(content, inc.lines); err != nil {
+			return nil, err
+		}
+	}
+	if inc.lang == "" && strings.HasSuffix(includedURL, ".md") {
+		if content, err = d.transclude(ctx, content, includedURL, chain); err != nil {
+			return nil, err
+		}
+		return d.rebaseLinks(content, includedURL)
+	}
+	return codeBlock(content, inc.lang), nil
+}
+
+// selectRegion returns the lines between the docforge:region and docforge:endregion markers with the given name
+func selectRegion(content []byte, region string) ([]byte, error) {
+	start := regexp.MustCompile(`docforge:region\s+` + regexp.QuoteMeta(region) + `(\s|$)`)
+	end := regexp.MustCompile(`docforge:endregion\s+` + regexp.QuoteMeta(region) + `(\s|$)`)
+	selected := []string{}
+	inRegion, found := false, false
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		switch {
+		case !inRegion && start.MatchString(line):
+			inRegion, found = true, true
+		case inRegion && end.MatchString(line):
+			return []byte(strings.Join(selected, "")), nil
+		case inRegion:
+			selected = append(selected, line)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("region %s not found", region)
+	}
+	return nil, fmt.Errorf("region %s is not closed", region)
+}
+
+// selectLines returns the lines from a 1-based inclusive range in the form 10-30, 10- or 10
+func selectLines(content []byte, lines string) ([]byte, error) {
+	all := strings.SplitAfter(string(content), "\n")
+	if all[len(all)-1] == "" {
+		all = all[:len(all)-1]
+	}
+	from, to, isRange := strings.Cut(lines, "-")
+	start, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return nil, fmt.Errorf("invalid lines %s: %w", lines, err)
+	}
+	end := start
+	if isRange {
+		end = len(all)
+		if strings.TrimSpace(to) != "" {
+			if end, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+				return nil, fmt.Errorf("invalid lines %s: %w", lines, err)
+			}
+		}
+	}
+	if start < 1 || end < start || end > len(all) {
+		return nil, fmt.Errorf("lines %s out of range 1-%d", lines, len(all))
+	}
+	return []byte(strings.Join(all[start-1:end], "")), nil
+}
+
+// codeBlock wraps content in a fenced code block longer than any backtick sequence in the content
+func codeBlock(content []byte, lang string) []byte {
+	fence := "```"
+	for strings.Contains(string(content), fence) {
+		fence += "`"
+	}
+	b := &bytes.Buffer{}
+	b.WriteString(fence + lang + "\n")
+	b.Write(content)
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		b.WriteString("\n")
+	}
+	b.WriteString(fence + "\n")
+	return b.Bytes()
+}
+
+// rebaseLinks makes the relative links of included markdown absolute so that they are
+// resolved against the included file instead of the including one. Frontmatter is dropped.
+func (d *Worker) rebaseLinks(content []byte, includedURL string) ([]byte, error) {
+	doc, err := markdown.Parse(d.markdown, content)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Kind() == ast.KindDocument {
+		clear(doc.(*ast.Document).Meta())
+	}
+	rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(func(dest string, _ bool) (string, error) {
+		if !repositoryhost.IsRelative(dest) || strings.HasPrefix(dest, "#") {
+			return dest, nil
+		}
+		return d.repositoryhosts.ResolveRelativeLink(includedURL, dest)
+	}))
+	b := &bytes.Buffer{}
+	if err := rnd.Render(b, content, doc); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package githubinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// Collector is a writer collecting the git info written for the nodes. The writes are passed on
+// to the next writer, if any.
+type Collector struct {
+	next writers.Writer
+
+	mutex sync.Mutex
+	infos map[*manifest.Node]*repositoryhost.GitInfo
+}
+
+// NewCollector creates a Collector passing the writes on to next, which can be nil
+func NewCollector(next writers.Writer) *Collector {
+	return &Collector{next: next, infos: map[*manifest.Node]*repositoryhost.GitInfo{}}
+}
+
+// Write collects the git info of a node and passes it on to the next writer
+func (c *Collector) Write(name, path string, content []byte, node *manifest.Node, indexFileNames []string) error {
+	if len(content) > 0 && node != nil {
+		info := &repositoryhost.GitInfo{}
+		if err := json.Unmarshal(content, info); err != nil {
+			return fmt.Errorf("failed to parse git info of node %s: %v", node.NodePath(), err)
+		}
+		c.mutex.Lock()
+		c.infos[node] = info
+		c.mutex.Unlock()
+	}
+	if c.next == nil {
+		return nil
+	}
+	return c.next.Write(name, path, content, node, indexFileNames)
+}
+
+// GitInfos returns the collected git info per node
+func (c *Collector) GitInfos() map[*manifest.Node]*repositoryhost.GitInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	infos := make(map[*manifest.Node]*repositoryhost.GitInfo, len(c.infos))
+	for node, info := range c.infos {
+		infos[node] = info
+	}
+	return infos
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package githubinfo_test
+
+import (
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collector", func() {
+	It("collects the git info of nodes and passes it on", func() {
+		next := &writersfakes.FakeWriter{}
+		collector := githubinfo.NewCollector(next)
+		a := &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "a.md"}}
+		b := &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "b.md"}}
+		Expect(collector.Write("a.md", "docs", []byte(`{"lastmod": "2024-01-01 10:00:00"}`), a, nil)).To(Succeed())
+		Expect(collector.Write("b.md", "docs", nil, b, nil)).To(Succeed())
+		Expect(collector.GitInfos()).To(Equal(map[*manifest.Node]*repositoryhost.GitInfo{a: {LastModifiedDate: github.String("2024-01-01 10:00:00")}}))
+		Expect(next.WriteCallCount()).To(Equal(2))
+		Expect(githubinfo.NewCollector(nil).Write("a.md", "docs", []byte(`{}`), a, nil)).To(Succeed())
+	})
+})
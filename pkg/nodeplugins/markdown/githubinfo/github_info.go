@@ -5,69 +5,101 @@
 package githubinfo
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/codeowners"
 	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
 	"github.com/gardener/docforge/pkg/writers"
 	"k8s.io/klog/v2"
 )
 
 // Worker github info worker
 type Worker struct {
-	registry registry.Interface
-	writer   writers.Writer
+	gitInfo    *Reader
+	writer     writers.Writer
+	codeowners *codeowners.Resolver
 }
 
-// NewGithubWorker creates new Worker object
-func NewGithubWorker(registry registry.Interface, writer writers.Writer) (*Worker, error) {
+// NewGithubWorker creates new Worker object. The code owners are only resolved when owners is true.
+func NewGithubWorker(registry registry.Interface, gitInfo *Reader, writer writers.Writer, owners bool) (*Worker, error) {
 	if registry == nil || reflect.ValueOf(registry).IsNil() {
 		return nil, errors.New("invalid argument: reader is nil")
 	}
+	if gitInfo == nil {
+		return nil, errors.New("invalid argument: git info reader is nil")
+	}
 	if writer == nil || reflect.ValueOf(writer).IsNil() {
 		return nil, errors.New("invalid argument: writer is nil")
 	}
+	var resolver *codeowners.Resolver
+	if owners {
+		resolver = codeowners.NewResolver(registry)
+	}
 	return &Worker{
-		registry,
+		gitInfo,
 		writer,
+		resolver,
 	}, nil
 }
 
 // WriteGithubInfo writes github info to writer for a given node
 func (w *Worker) WriteGithubInfo(ctx context.Context, node *manifest.Node) error {
-	var (
-		b       bytes.Buffer
-		info    []byte
-		err     error
-		sources []string
-	)
-	if len(node.Source) > 0 {
-		sources = append(sources, node.Source)
-	}
-	sources = append(sources, node.MultiSource...)
-
+	sources := nodeSources(node)
 	if len(sources) == 0 {
 		klog.V(6).Infof("skip git info for container node: %v\n", node)
 		return nil
 	}
-	for _, s := range sources {
-		klog.V(6).Infof("reading git info for %s\n", s)
-		// read github info
-		if info, err = w.registry.ReadGitInfo(ctx, s); err != nil {
-			return fmt.Errorf("failed to read git info for %s: %v", s, err)
+	info, err := w.gitInfo.Read(ctx, node)
+	if err != nil {
+		return err
+	}
+	var content []byte
+	if info != nil {
+		if w.codeowners != nil {
+			if info.Owners, err = w.codeowners.Owners(ctx, sources); err != nil {
+				return fmt.Errorf("failed to read code owners of node %s: %v", node.NodePath(), err)
+			}
 		}
-		if info != nil {
-			b.Write(info)
+		if content, err = json.MarshalIndent(info, "", "  "); err != nil {
+			return err
 		}
 	}
 	nodePath := node.Path
 	klog.V(6).Infof("writing git info for node %s/%s\n", nodePath, node.Name())
-	if err = w.writer.Write(node.Name(), nodePath, b.Bytes(), node, nil); err != nil {
+	if err = w.writer.Write(node.Name(), nodePath, content, node, nil); err != nil {
 		return err
 	}
 	return nil
 }
+
+// ReadNodeGitInfo reads the git info of the sources of a node, which is nil when the sources have no commits.
+// The git info of the sources of a multiSource node is merged.
+func ReadNodeGitInfo(ctx context.Context, r registry.Interface, sources []string) (*repositoryhost.GitInfo, error) {
+	var infos []*repositoryhost.GitInfo
+	for _, s := range sources {
+		klog.V(6).Infof("reading git info for %s\n", s)
+		// read github info
+		info, err := r.ReadGitInfo(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read git info for %s: %v", s, err)
+		}
+		var gitInfo *repositoryhost.GitInfo
+		if info != nil {
+			gitInfo = &repositoryhost.GitInfo{}
+			if err = json.Unmarshal(info, gitInfo); err != nil {
+				return nil, fmt.Errorf("failed to parse git info for %s: %v", s, err)
+			}
+		}
+		infos = append(infos, gitInfo)
+	}
+	if len(infos) == 1 {
+		return infos[0], nil
+	}
+	return repositoryhost.MergeGitInfo(sources, infos), nil
+}
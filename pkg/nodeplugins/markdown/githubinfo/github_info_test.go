@@ -6,6 +6,7 @@ package githubinfo_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/gardener/docforge/pkg/registry/registryfakes"
 	"github.com/gardener/docforge/pkg/registry/repositoryhost"
 	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	"github.com/google/go-github/v43/github"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -33,6 +35,7 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 
 		ctx      context.Context
 		taskNode *manifest.Node
+		owners   bool
 	)
 
 	BeforeEach(func() {
@@ -40,13 +43,13 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 		writer = &writersfakes.FakeWriter{}
 		registry.ReadGitInfoCalls(func(ctx context.Context, s string) ([]byte, error) {
 			if s == "https://github.com/gardener/docforge/blob/master/README.md" {
-				return []byte("repoHost1 source_content\n"), nil
+				return []byte(`{"lastmod": "2024-02-07 13:11:00", "publishdate": "2024-02-01 10:00:00", "author": {"name": "one", "email": "one@"}, "weburl": "readme", "shaalias": "master", "path": "README.md"}`), nil
 			}
 			if s == "https://github.com/gardener/docforge/blob/feature/A.md" {
-				return []byte("repoHost2 multi_source_content\n"), nil
+				return []byte(`{"lastmod": "2024-03-01 09:00:00", "publishdate": "2024-02-03 10:00:00", "author": {"name": "two", "email": "two@"}, "contributors": [{"name": "one", "email": "one@"}, {"name": "three", "email": "three@"}], "weburl": "a", "path": "A.md"}`), nil
 			}
 			if s == "https://github.com/gardener/docforge/blob/feature/B.md" {
-				return []byte("repoHost2 multi_source_content 2\n"), nil
+				return []byte(`{"lastmod": "2024-01-05 09:00:00", "publishdate": "2024-01-02 10:00:00", "author": {"login": "four"}, "weburl": "b", "path": "B.md"}`), nil
 			}
 			if s == "https://github.com/gardener/docforge/blob/feature/C.md" {
 				return nil, nil
@@ -55,6 +58,7 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 		})
 		writer.WriteReturns(nil)
 		ctx = context.Background()
+		owners = false
 		taskNode = &manifest.Node{
 			Type: "file",
 			FileType: manifest.FileType{
@@ -66,7 +70,7 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 	})
 
 	JustBeforeEach(func() {
-		worker, err = githubinfo.NewGithubWorker(registry, writer)
+		worker, err = githubinfo.NewGithubWorker(registry, githubinfo.NewReader(registry), writer, owners)
 		Expect(worker).NotTo(BeNil())
 		Expect(err).NotTo(HaveOccurred())
 
@@ -93,14 +97,46 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 		})
 	})
 
-	Context("github info read for repoHost2://fake_multi_source returns nil []byte", func() {
+	Context("github info read for https://github.com/gardener/docforge/blob/feature/C.md returns nil []byte", func() {
 		BeforeEach(func() {
 			taskNode.MultiSource[1] = "https://github.com/gardener/docforge/blob/feature/C.md"
 		})
-		It("succeeded", func() {
+		It("merges the other sources", func() {
 			Expect(err).NotTo(HaveOccurred())
 			_, _, content, _, _ := writer.WriteArgsForCall(0)
-			Expect(string(content)).To(Equal("repoHost1 source_content\nrepoHost2 multi_source_content\n"))
+			info := &repositoryhost.GitInfo{}
+			Expect(json.Unmarshal(content, info)).To(Succeed())
+			Expect(info.GetLastModifiedDate()).To(Equal("2024-03-01 09:00:00"))
+			Expect(info.GetPublishDate()).To(Equal("2024-02-01 10:00:00"))
+			Expect(info.Sources).To(HaveLen(2))
+		})
+	})
+
+	Context("node with a single source", func() {
+		BeforeEach(func() {
+			taskNode.MultiSource = nil
+		})
+		It("writes the git info of the source", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, _, content, _, _ := writer.WriteArgsForCall(0)
+			Expect(content).To(MatchJSON(`{"lastmod": "2024-02-07 13:11:00", "publishdate": "2024-02-01 10:00:00", "author": {"name": "one", "email": "one@"}, "weburl": "readme", "shaalias": "master", "path": "README.md"}`))
+		})
+	})
+
+	Context("owners not requested", func() {
+		It("doesn't resolve the code owners", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.ResourceURLCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("owners requested", func() {
+		BeforeEach(func() {
+			owners = true
+		})
+		It("resolves the code owners of the sources", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.ResourceURLCallCount()).To(Equal(3))
 		})
 	})
 
@@ -122,6 +158,21 @@ var _ = Describe("Executing WriteGithubInfo", func() {
 		Expect(node.Source).To(Equal("https://github.com/gardener/docforge/blob/master/README.md"))
 		Expect(path).To(Equal(""))
 		Expect(name).To(Equal("README.md"))
-		Expect(string(content)).To(Equal("repoHost1 source_content\nrepoHost2 multi_source_content\nrepoHost2 multi_source_content 2\n"))
+		info := &repositoryhost.GitInfo{}
+		Expect(json.Unmarshal(content, info)).To(Succeed())
+		Expect(info.GetLastModifiedDate()).To(Equal("2024-03-01 09:00:00"))
+		Expect(info.GetPublishDate()).To(Equal("2024-01-02 10:00:00"))
+		Expect(info.Author.GetLogin()).To(Equal("four"))
+		Expect(info.WebURL).To(Equal(github.String("readme")))
+		Expect(info.SHAAlias).To(Equal(github.String("master")))
+		Expect(info.Path).To(Equal(github.String("README.md")))
+		names := []string{}
+		for _, contributor := range info.Contributors {
+			names = append(names, contributor.GetName())
+		}
+		Expect(names).To(Equal([]string{"one", "two", "three"}))
+		Expect(info.Sources).To(HaveLen(3))
+		Expect(info.Sources[1].Source).To(Equal(github.String("https://github.com/gardener/docforge/blob/feature/A.md")))
+		Expect(info.Sources[1].GetLastModifiedDate()).To(Equal("2024-03-01 09:00:00"))
 	})
 })
@@ -31,8 +31,8 @@ type gitHubInfo struct {
 }
 
 // New creates GitHubInfo object for writing GitHub infos
-func New(workerCount int, failFast bool, wg *sync.WaitGroup, registry registry.Interface, writer writers.Writer) (GitHubInfo, taskqueue.QueueController, error) {
-	ghInfoWorker, err := NewGithubWorker(registry, writer)
+func New(workerCount int, failFast bool, wg *sync.WaitGroup, registry registry.Interface, gitInfo *Reader, writer writers.Writer, owners bool) (GitHubInfo, taskqueue.QueueController, error) {
+	ghInfoWorker, err := NewGithubWorker(registry, gitInfo, writer, owners)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package githubinfo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+)
+
+// Reader reads the git info of the nodes once, so that the document worker injecting it into
+// the frontmatter and the git info worker writing it share the result
+type Reader struct {
+	registry registry.Interface
+
+	mutex sync.Mutex
+	nodes map[*manifest.Node]*nodeGitInfo
+}
+
+type nodeGitInfo struct {
+	once sync.Once
+	info *repositoryhost.GitInfo
+	err  error
+}
+
+// NewReader creates a Reader reading the git info from registry
+func NewReader(registry registry.Interface) *Reader {
+	return &Reader{registry: registry, nodes: map[*manifest.Node]*nodeGitInfo{}}
+}
+
+// Read returns the git info of the sources of a node, which is nil for nodes without sources
+// or with sources without commits
+func (r *Reader) Read(ctx context.Context, node *manifest.Node) (*repositoryhost.GitInfo, error) {
+	sources := nodeSources(node)
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	r.mutex.Lock()
+	n, ok := r.nodes[node]
+	if !ok {
+		n = &nodeGitInfo{}
+		r.nodes[node] = n
+	}
+	r.mutex.Unlock()
+	n.once.Do(func() {
+		n.info, n.err = ReadNodeGitInfo(ctx, r.registry, sources)
+	})
+	return n.info, n.err
+}
+
+func nodeSources(node *manifest.Node) []string {
+	var sources []string
+	if len(node.Source) > 0 {
+		sources = append(sources, node.Source)
+	}
+	return append(sources, node.MultiSource...)
+}
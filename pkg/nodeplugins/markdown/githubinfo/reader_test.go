@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package githubinfo_test
+
+import (
+	"context"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
+	"github.com/gardener/docforge/pkg/registry/registryfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reader", func() {
+	It("reads the git info of a node once", func() {
+		registry := &registryfakes.FakeInterface{}
+		registry.ReadGitInfoReturns([]byte(`{"lastmod": "2024-02-07 13:11:00"}`), nil)
+		reader := githubinfo.NewReader(registry)
+		node := &manifest.Node{Type: "file", FileType: manifest.FileType{File: "README.md", Source: "https://github.com/gardener/docforge/blob/master/README.md"}}
+		first, err := reader.Read(context.Background(), node)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := reader.Read(context.Background(), node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(first.GetLastModifiedDate()).To(Equal("2024-02-07 13:11:00"))
+		Expect(registry.ReadGitInfoCallCount()).To(Equal(1))
+	})
+
+	It("returns no git info for nodes without sources", func() {
+		registry := &registryfakes.FakeInterface{}
+		info, err := githubinfo.NewReader(registry).Read(context.Background(), &manifest.Node{Type: "dir", DirType: manifest.DirType{Dir: "folder"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).To(BeNil())
+		Expect(registry.ReadGitInfoCallCount()).To(Equal(0))
+	})
+})
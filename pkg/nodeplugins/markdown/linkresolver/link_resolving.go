@@ -7,7 +7,10 @@ package linkresolver
 import (
 	"cmp"
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -19,6 +22,9 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// matches the tokens of permalink patterns, e.g. :sections
+var permalinkToken = regexp.MustCompile(`:[a-z]+`)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate -header ../../../../license_prefix.txt
 
 // Interface resolves links URLs
@@ -28,6 +34,7 @@ import (
 // Interface represent link resolving interface
 type Interface interface {
 	ResolveResourceLink(destination string, node *manifest.Node, source string) (string, error)
+	ResolveResourceURL(destination string, node *manifest.Node, source string) (string, error)
 }
 
 // LinkResolver represents link resolving nessesary objects
@@ -35,6 +42,16 @@ type LinkResolver struct {
 	Repositoryhosts registry.Interface
 	SourceToNode    map[string][]*manifest.Node
 	Hugo            hugo.Hugo
+
+	// pages are the titles and slugs of the nodes in the manifest frontmatter. They are read before
+	// the documents are processed, as the workers merge the document frontmatter into the nodes.
+	pages map[*manifest.Node]page
+}
+
+// page is the frontmatter of a node used by permalink patterns
+type page struct {
+	title string
+	slug  string
 }
 
 // New creates a new linkresolver given the manifest structure and a registry used for working with links
@@ -43,8 +60,12 @@ func New(structure []*manifest.Node, rhs registry.Interface, hugo hugo.Hugo) *Li
 		Repositoryhosts: rhs,
 		Hugo:            hugo,
 		SourceToNode:    make(map[string][]*manifest.Node),
+		pages:           make(map[*manifest.Node]page),
 	}
 	for _, node := range structure {
+		title, _ := node.Frontmatter["title"].(string)
+		slug, _ := node.Frontmatter["slug"].(string)
+		lr.pages[node] = page{title: title, slug: slug}
 		if node.Source != "" {
 			lr.SourceToNode[node.Source] = append(lr.SourceToNode[node.Source], node)
 		} else if len(node.MultiSource) > 0 {
@@ -58,6 +79,16 @@ func New(structure []*manifest.Node, rhs registry.Interface, hugo hugo.Hugo) *Li
 
 // ResolveResourceLink resolves resource link from a given source
 func (l *LinkResolver) ResolveResourceLink(resourceLink string, node *manifest.Node, source string) (string, error) {
+	return l.resolveResourceLink(resourceLink, node, source, l.Hugo.Enabled && l.Hugo.RelrefLinks)
+}
+
+// ResolveResourceURL resolves resource link from a given source to a URL, also in relref mode.
+// It is used for the links in raw HTML and diagrams, in which Hugo doesn't expand shortcodes.
+func (l *LinkResolver) ResolveResourceURL(resourceLink string, node *manifest.Node, source string) (string, error) {
+	return l.resolveResourceLink(resourceLink, node, source, false)
+}
+
+func (l *LinkResolver) resolveResourceLink(resourceLink string, node *manifest.Node, source string, relref bool) (string, error) {
 	// handle relative links to resources
 	if repositoryhost.IsRelative(resourceLink) {
 		var err error
@@ -82,20 +113,149 @@ func (l *LinkResolver) ResolveResourceLink(resourceLink string, node *manifest.N
 		return resourceLink, err
 	}
 
-	// construct destination from node path
-	websiteLink := strings.ToLower(destinationNode.NodePath())
-	if l.Hugo.Enabled {
-		websiteLink = strings.ToLower(destinationNode.HugoPrettyPath())
+	if relref && strings.HasSuffix(destinationNode.Name(), ".md") {
+		return l.relref(destinationNode, node, destinationResource.GetResourceSuffix())
+	}
+	websiteLink, err := l.websiteLink(destinationNode)
+	if err != nil {
+		return resourceLink, err
+	}
+	suffix := destinationResource.GetResourceSuffix()
+	switch l.Hugo.LinkStyle {
+	case hugo.LinkStyleRelative:
+		nodeLink, err := l.websiteLink(node)
+		if err != nil {
+			return resourceLink, err
+		}
+		return relativeLink(nodeLink, websiteLink) + suffix, nil
+	case hugo.LinkStyleAbsolute:
+		return link.Build(l.Hugo.BaseURL, websiteLink, suffix)
 	}
-	for _, structuralDir := range l.Hugo.HugoStructuralDirs {
-		websiteLink = strings.TrimPrefix(websiteLink, structuralDir+"/")
+	if suffix != "" {
+		return link.Build("/", l.Hugo.BaseURL, websiteLink, suffix)
 	}
-	if destinationResource.GetResourceSuffix() != "" {
-		return link.Build("/", l.Hugo.BaseURL, websiteLink, destinationResource.GetResourceSuffix())
+	return link.Build("/", l.Hugo.BaseURL, websiteLink)
+}
+
+// WebsiteURL returns the URL of the page or resource of a node on the website, with the base URL
+func (l *LinkResolver) WebsiteURL(node *manifest.Node) (string, error) {
+	websiteLink, err := l.websiteLink(node)
+	if err != nil {
+		return "", err
+	}
+	if baseURL, err := url.Parse(l.Hugo.BaseURL); err == nil && baseURL.IsAbs() {
+		return link.Build(l.Hugo.BaseURL, websiteLink)
 	}
 	return link.Build("/", l.Hugo.BaseURL, websiteLink)
 }
 
+// websiteLink returns the path of the page or resource of a node on the website, without the base URL
+func (l *LinkResolver) websiteLink(node *manifest.Node) (string, error) {
+	websiteLink := node.NodePath()
+	if l.Hugo.Enabled {
+		websiteLink = node.HugoPrettyPath()
+		for _, structuralDir := range l.Hugo.HugoStructuralDirs {
+			websiteLink = strings.TrimPrefix(websiteLink, structuralDir+"/")
+		}
+		var err error
+		if websiteLink, err = l.permalink(node, websiteLink); err != nil {
+			return "", err
+		}
+	}
+	if !l.Hugo.DisablePathToLower {
+		websiteLink = strings.ToLower(websiteLink)
+	}
+	return websiteLink, nil
+}
+
+// permalink applies the permalink pattern of the node section, the same way Hugo does for regular pages.
+// Supported tokens are :section, :sections, :filename, :contentbasename, :slug and :title.
+func (l *LinkResolver) permalink(node *manifest.Node, websiteLink string) (string, error) {
+	name := node.Name()
+	if !strings.HasSuffix(name, ".md") || name == "_index.md" || slices.Contains(l.Hugo.IndexFileNames, name) {
+		return websiteLink, nil
+	}
+	sections := path.Dir(strings.TrimSuffix(websiteLink, "/"))
+	section, _, _ := strings.Cut(sections, "/")
+	pattern, ok := l.Hugo.Permalinks[section]
+	if !ok || sections == "." {
+		return websiteLink, nil
+	}
+	filename := strings.TrimSuffix(name, ".md")
+	title := filename
+	p := l.pages[node]
+	if p.title != "" {
+		title = urlize(p.title)
+	}
+	slug := title
+	if p.slug != "" {
+		slug = urlize(p.slug)
+	}
+	var err error
+	permalink := permalinkToken.ReplaceAllStringFunc(pattern, func(token string) string {
+		switch token {
+		case ":section":
+			return section
+		case ":sections":
+			return sections
+		case ":filename", ":contentbasename":
+			return filename
+		case ":slug":
+			return slug
+		case ":title":
+			return title
+		}
+		err = fmt.Errorf("permalink token %s of section %s is not supported", token, section)
+		return token
+	})
+	return strings.TrimPrefix(permalink, "/"), err
+}
+
+// urlize converts a frontmatter value to a path segment, e.g. Getting Started -> Getting-Started
+func urlize(value string) string {
+	return strings.Join(strings.Fields(value), "-")
+}
+
+// relativeLink returns the link to a website path relative to the page at from.
+// Pages with pretty URLs are directories, so links are relative to the page itself.
+func relativeLink(from string, to string) string {
+	fromDir := from
+	if !strings.HasSuffix(from, "/") {
+		fromDir = path.Dir(from)
+	}
+	relPath, err := filepath.Rel(fromDir, to)
+	if err != nil {
+		return to
+	}
+	relPath = filepath.ToSlash(relPath)
+	if strings.HasSuffix(to, "/") {
+		relPath += "/"
+	}
+	if relPath == "./" {
+		return relPath
+	}
+	return strings.TrimPrefix(relPath, "./")
+}
+
+// relref returns a Hugo relref shortcode referencing the destination document relative to the node,
+// which lets Hugo compute the URL and fail on broken links
+func (l *LinkResolver) relref(destinationNode *manifest.Node, node *manifest.Node, suffix string) (string, error) {
+	name := destinationNode.Name()
+	if slices.Contains(l.Hugo.IndexFileNames, name) {
+		name = "_index.md"
+	}
+	relPath, err := filepath.Rel(node.Path, destinationNode.Path)
+	if err != nil {
+		return "", err
+	}
+	ref := path.Join(filepath.ToSlash(relPath), name)
+	// relref supports anchors only
+	if _, anchor, ok := strings.Cut(suffix, "#"); ok {
+		ref += "#" + anchor
+	}
+	return fmt.Sprintf(`{{< relref "%s" >}}`, ref), nil
+}
+
 func (l *LinkResolver) resolveDestinationNode(destinationResourceURL string, node *manifest.Node) (*manifest.Node, error) {
 	// check if link refers to a node
 	nl, ok := l.SourceToNode[destinationResourceURL]
@@ -114,7 +274,8 @@ func (l *LinkResolver) resolveDestinationNode(destinationResourceURL string, nod
 		return destinationNode, nil
 	}
 	// resolve linkResolution override
-	candidateNodes := slices.DeleteFunc(nl, func(element *manifest.Node) bool {
+	// the nodes with this source are shared by all documents and must not be modified
+	candidateNodes := slices.DeleteFunc(slices.Clone(nl), func(element *manifest.Node) bool {
 		return element.NodePath() != desiredPath
 	})
 	if len(candidateNodes) != 1 {
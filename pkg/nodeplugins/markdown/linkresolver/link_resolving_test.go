@@ -6,6 +6,8 @@ package linkresolver_test
 
 import (
 	"embed"
+	"fmt"
+	"sync"
 	"testing"
 
 	_ "embed"
@@ -30,31 +32,21 @@ var manifests embed.FS
 var _ = Describe("Document link resolving", func() {
 	Context("#ResolveResourceLink", func() {
 		var (
-			linkResolver linkresolver.LinkResolver
+			linkResolver *linkresolver.LinkResolver
+			nodes        []*manifest.Node
 			node         *manifest.Node
 			source       string
 		)
 
 		BeforeEach(func() {
-			linkResolver = linkresolver.LinkResolver{}
+			var err error
 			registry := registry.NewRegistry(repositoryhost.NewLocalTest(manifests, "https://github.com/gardener/docforge", "tests"))
-			linkResolver.Repositoryhosts = registry
-			linkResolver.Hugo = hugo.Hugo{
+			nodes, err = manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/baseline.yaml", registry)
+			Expect(err).NotTo(HaveOccurred())
+			linkResolver = linkresolver.New(nodes, registry, hugo.Hugo{
 				Enabled: true,
 				BaseURL: "baseURL",
-			}
-			linkResolver.SourceToNode = make(map[string][]*manifest.Node)
-			nodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/baseline.yaml", linkResolver.Repositoryhosts)
-			Expect(err).NotTo(HaveOccurred())
-			for _, node := range nodes {
-				if node.Source != "" {
-					linkResolver.SourceToNode[node.Source] = append(linkResolver.SourceToNode[node.Source], node)
-				} else if len(node.MultiSource) > 0 {
-					for _, s := range node.MultiSource {
-						linkResolver.SourceToNode[s] = append(linkResolver.SourceToNode[s], node)
-					}
-				}
-			}
+			})
 			source = "https://github.com/gardener/docforge/blob/master/target.md"
 			node = linkResolver.SourceToNode[source][0]
 		})
@@ -107,6 +99,127 @@ var _ = Describe("Document link resolving", func() {
 			Expect(newLink).To(Equal("/baseURL/docs/file/"))
 		})
 
+		Context("Resolving links with a link style", func() {
+			It("resolves relative links", func() {
+				linkResolver.Hugo.LinkStyle = hugo.LinkStyleRelative
+				newLink, err := linkResolver.ResolveResourceLink("clickhere.md?a=b#c", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("../internal/linked/?a=b#c"))
+				newLink, err = linkResolver.ResolveResourceLink("#anchor", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("./#anchor"))
+			})
+
+			It("resolves absolute links", func() {
+				linkResolver.Hugo.LinkStyle = hugo.LinkStyleAbsolute
+				linkResolver.Hugo.BaseURL = "https://gardener.cloud/docs"
+				newLink, err := linkResolver.ResolveResourceLink("clickhere.md#anchor", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("https://gardener.cloud/docs/one/internal/linked/#anchor"))
+			})
+		})
+
+		Context("Resolving links with permalinks", func() {
+			const farLink = "https://github.com/gardener/docforge/blob/master/linkresolution.md"
+
+			BeforeEach(func() {
+				for _, n := range linkResolver.SourceToNode[farLink] {
+					n.Frontmatter = map[string]interface{}{"title": "Far Link"}
+				}
+				// the titles are read from the manifest frontmatter when the resolver is created
+				linkResolver = linkresolver.New(nodes, linkResolver.Repositoryhosts, linkResolver.Hugo)
+				node = linkResolver.SourceToNode[source][0]
+			})
+
+			It("applies the permalink pattern of the section", func() {
+				linkResolver.Hugo.Permalinks = map[string]string{"two": "/:sections/:filename"}
+				newLink, err := linkResolver.ResolveResourceLink(farLink, node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("/baseURL/two/internal/far_linkresolution"))
+			})
+
+			It("keeps the case of the path if configured", func() {
+				linkResolver.Hugo.Permalinks = map[string]string{"two": "/:section/:title/"}
+				newLink, err := linkResolver.ResolveResourceLink(farLink, node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("/baseURL/two/far-link/"))
+				linkResolver.Hugo.DisablePathToLower = true
+				newLink, err = linkResolver.ResolveResourceLink(farLink, node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("/baseURL/two/Far-Link/"))
+			})
+
+			It("ignores the frontmatter merged into the nodes while links are resolved", func() {
+				linkResolver.Hugo.Permalinks = map[string]string{"two": "/:section/:title/"}
+				var wg sync.WaitGroup
+				for _, far := range linkResolver.SourceToNode[farLink] {
+					wg.Add(1)
+					go func(far *manifest.Node) {
+						defer wg.Done()
+						for i := 0; i < 100; i++ {
+							far.Frontmatter["title"] = fmt.Sprintf("Document Title %d", i)
+						}
+					}(far)
+				}
+				links := make([]string, 10)
+				for i := range links {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						defer GinkgoRecover()
+						var err error
+						links[i], err = linkResolver.ResolveResourceLink(farLink, node, source)
+						Expect(err).ToNot(HaveOccurred())
+					}(i)
+				}
+				wg.Wait()
+				for _, link := range links {
+					Expect(link).To(Equal("/baseURL/two/far-link/"))
+				}
+			})
+
+			It("fails on unsupported tokens", func() {
+				linkResolver.Hugo.Permalinks = map[string]string{"two": "/:year/:slug/"}
+				_, err := linkResolver.ResolveResourceLink(farLink, node, source)
+				Expect(err).To(MatchError(ContainSubstring("permalink token :year of section two is not supported")))
+			})
+		})
+
+		Context("Resolving links as relref shortcodes", func() {
+			BeforeEach(func() {
+				linkResolver.Hugo.RelrefLinks = true
+				linkResolver.Hugo.IndexFileNames = []string{"readme.md", "_index.md"}
+				linkResolver.Hugo.HugoStructuralDirs = []string{"content"}
+			})
+
+			It("references documents relative to the node", func() {
+				newLink, err := linkResolver.ResolveResourceLink("clickhere.md?a=b#c", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal(`{{< relref "internal/linked.md#c" >}}`))
+			})
+
+			It("references section files and structural dirs by their file path", func() {
+				newLink, err := linkResolver.ResolveResourceLink("https://github.com/gardener/docforge/blob/master/docs/_index.md", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal(`{{< relref "../two/internal/_index.md" >}}`))
+				newLink, err = linkResolver.ResolveResourceLink("https://github.com/gardener/docforge/blob/master/file.md", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal(`{{< relref "../content/docs/file.md" >}}`))
+			})
+
+			It("keeps links to broken and non-page resources", func() {
+				newLink, err := linkResolver.ResolveResourceLink("./non-page.md", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("https://github.com/gardener/docforge/blob/master/non-page.md"))
+			})
+
+			It("resolves URLs for links that can't be shortcodes", func() {
+				newLink, err := linkResolver.ResolveResourceURL("clickhere.md?a=b#c", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("/baseURL/one/internal/linked/?a=b#c"))
+			})
+		})
+
 		Context("Resolving URL from linkResolution", func() {
 			It("Resolves it correctly", func() {
 				By("Node having no linkResolution should map to closest node")
@@ -134,5 +247,17 @@ var _ = Describe("Document link resolving", func() {
 				Expect(newLink).To(Equal("https://github.com/gardener/docforge/blob/master/linkresolution3.md"))
 			})
 		})
+
+		Context("Resolving the URL of a node", func() {
+			It("returns the page URL with the base URL", func() {
+				websiteURL, err := linkResolver.WebsiteURL(node)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(websiteURL).To(Equal("/baseURL/one/node/"))
+				linkResolver.Hugo.BaseURL = "https://gardener.cloud/docs"
+				websiteURL, err = linkResolver.WebsiteURL(node)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(websiteURL).To(Equal("https://gardener.cloud/docs/one/node/"))
+			})
+		})
 	})
 })
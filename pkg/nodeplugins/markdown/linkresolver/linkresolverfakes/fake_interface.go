@@ -27,6 +27,21 @@ type FakeInterface struct {
 		result1 string
 		result2 error
 	}
+	ResolveResourceURLStub        func(string, *manifest.Node, string) (string, error)
+	resolveResourceURLMutex       sync.RWMutex
+	resolveResourceURLArgsForCall []struct {
+		arg1 string
+		arg2 *manifest.Node
+		arg3 string
+	}
+	resolveResourceURLReturns struct {
+		result1 string
+		result2 error
+	}
+	resolveResourceURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -97,11 +112,79 @@ func (fake *FakeInterface) ResolveResourceLinkReturnsOnCall(i int, result1 strin
 	}{result1, result2}
 }
 
+func (fake *FakeInterface) ResolveResourceURL(arg1 string, arg2 *manifest.Node, arg3 string) (string, error) {
+	fake.resolveResourceURLMutex.Lock()
+	ret, specificReturn := fake.resolveResourceURLReturnsOnCall[len(fake.resolveResourceURLArgsForCall)]
+	fake.resolveResourceURLArgsForCall = append(fake.resolveResourceURLArgsForCall, struct {
+		arg1 string
+		arg2 *manifest.Node
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ResolveResourceURLStub
+	fakeReturns := fake.resolveResourceURLReturns
+	fake.recordInvocation("ResolveResourceURL", []interface{}{arg1, arg2, arg3})
+	fake.resolveResourceURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInterface) ResolveResourceURLCallCount() int {
+	fake.resolveResourceURLMutex.RLock()
+	defer fake.resolveResourceURLMutex.RUnlock()
+	return len(fake.resolveResourceURLArgsForCall)
+}
+
+func (fake *FakeInterface) ResolveResourceURLCalls(stub func(string, *manifest.Node, string) (string, error)) {
+	fake.resolveResourceURLMutex.Lock()
+	defer fake.resolveResourceURLMutex.Unlock()
+	fake.ResolveResourceURLStub = stub
+}
+
+func (fake *FakeInterface) ResolveResourceURLArgsForCall(i int) (string, *manifest.Node, string) {
+	fake.resolveResourceURLMutex.RLock()
+	defer fake.resolveResourceURLMutex.RUnlock()
+	argsForCall := fake.resolveResourceURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeInterface) ResolveResourceURLReturns(result1 string, result2 error) {
+	fake.resolveResourceURLMutex.Lock()
+	defer fake.resolveResourceURLMutex.Unlock()
+	fake.ResolveResourceURLStub = nil
+	fake.resolveResourceURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInterface) ResolveResourceURLReturnsOnCall(i int, result1 string, result2 error) {
+	fake.resolveResourceURLMutex.Lock()
+	defer fake.resolveResourceURLMutex.Unlock()
+	fake.ResolveResourceURLStub = nil
+	if fake.resolveResourceURLReturnsOnCall == nil {
+		fake.resolveResourceURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.resolveResourceURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.resolveResourceLinkMutex.RLock()
 	defer fake.resolveResourceLinkMutex.RUnlock()
+	fake.resolveResourceURLMutex.RLock()
+	defer fake.resolveResourceURLMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -9,6 +9,7 @@ import (
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/githubinfo"
 	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkvalidator"
+	"github.com/gardener/docforge/pkg/postprocessors"
 	"github.com/gardener/docforge/pkg/registry"
 	"github.com/gardener/docforge/pkg/workers/taskqueue"
 	"github.com/gardener/docforge/pkg/writers"
@@ -20,15 +21,17 @@ type plugin struct {
 }
 
 // NewPlugin creates a new markdown plugin
-func NewPlugin(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, rhs registry.Interface, hugo hugo.Hugo, writer writers.Writer, skipLinkValidation bool, validationWorkersCount int, hostsToReport []string, resourceDownloadWorkersCount int, gitInfoWriter writers.Writer) (nodeplugins.Interface, []taskqueue.QueueController, error) {
+func NewPlugin(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, rhs registry.Interface, hugo hugo.Hugo, writer writers.Writer, documents postprocessors.DocumentRecorder, skipLinkValidation bool, validationWorkersCount int, hostsToReport []string, resourceDownloadWorkersCount int, gitInfoWriter writers.Writer, gitInfoOwners bool, maxIncludeDepth int) (nodeplugins.Interface, []taskqueue.QueueController, error) {
 	var (
 		ghInfo      githubinfo.GitHubInfo
 		ghInfoTasks taskqueue.QueueController
 		err         error
 	)
 	queues := []taskqueue.QueueController{}
+	// the git info of a node is read once for its frontmatter and the git info writer
+	gitInfo := githubinfo.NewReader(rhs)
 	if gitInfoWriter != nil {
-		ghInfo, ghInfoTasks, err = githubinfo.New(resourceDownloadWorkersCount, failFast, wg, rhs, gitInfoWriter)
+		ghInfo, ghInfoTasks, err = githubinfo.New(resourceDownloadWorkersCount, failFast, wg, rhs, gitInfo, gitInfoWriter, gitInfoOwners)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -38,7 +41,7 @@ func NewPlugin(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*
 	if err != nil {
 		return nil, nil, err
 	}
-	docProcessor, docTasks, err := document.New(workerCount, failFast, wg, structure, validator, rhs, hugo, writer, skipLinkValidation)
+	docProcessor, docTasks, err := document.New(workerCount, failFast, wg, structure, validator, rhs, gitInfo, hugo, writer, documents, skipLinkValidation, maxIncludeDepth)
 	return &plugin{docProcessor, ghInfo}, append(queues, validatorTasks, docTasks), err
 }
 
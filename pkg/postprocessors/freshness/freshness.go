@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package freshness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers"
+	"github.com/google/go-github/v43/github"
+	"k8s.io/klog/v2"
+)
+
+// PostProcessor writes the freshness report of the documents of a bundle as JSON document and as markdown page
+type PostProcessor struct {
+	Registry registry.Interface
+	Writer   writers.Writer
+	// Path of the report in the bundle, without extension
+	Path string
+	// StaleMonths is the number of months after which a page that is not modified is stale
+	StaleMonths int
+	// RepositoryLagMonths is the number of months a page can lag behind the latest commit in its source repository
+	RepositoryLagMonths int
+	// FrontmatterFormat of the markdown page, yaml by default
+	FrontmatterFormat string
+}
+
+// Name returns the name of the freshness post-processor
+func (PostProcessor) Name() string {
+	return "freshness"
+}
+
+// PostProcess writes the freshness report of the git info of the documents of a bundle, generated at the bundle time
+func (p *PostProcessor) PostProcess(ctx context.Context, bundle *postprocessors.Bundle) error {
+	report, err := Generate(ctx, p.Registry, bundle.GitInfos, Options{
+		StaleMonths:         p.StaleMonths,
+		RepositoryLagMonths: p.RepositoryLagMonths,
+		Now:                 bundle.Time,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate the freshness report: %w", err)
+	}
+	content, err := report.JSON()
+	if err != nil {
+		return err
+	}
+	dir, name := path.Split(p.Path)
+	if err = p.Writer.Write(name+".json", dir, content, nil, nil); err != nil {
+		return err
+	}
+	if content, err = report.Markdown(p.FrontmatterFormat); err != nil {
+		return err
+	}
+	return p.Writer.Write(name+".md", dir, content, nil, nil)
+}
+
+// Options configure the freshness report
+type Options struct {
+	// StaleMonths is the number of months after which a page that is not modified is stale
+	StaleMonths int
+	// RepositoryLagMonths is the number of months a page can lag behind the latest commit in its source repository
+	RepositoryLagMonths int
+	// Now is the time the report is generated at
+	Now time.Time
+}
+
+// Report lists the pages that need the attention of their owners
+type Report struct {
+	Generated           string `json:"generated"`
+	StaleMonths         int    `json:"staleMonths"`
+	RepositoryLagMonths int    `json:"repositoryLagMonths"`
+	// Stale are the pages not modified in the last StaleMonths
+	Stale []*Entry `json:"stale"`
+	// BehindRepository are the pages whose source repository is modified more than RepositoryLagMonths after them
+	BehindRepository []*Entry `json:"behindRepository"`
+	// SingleContributor are the pages with a single contributor
+	SingleContributor []*Entry `json:"singleContributor"`
+}
+
+// Entry describes a page in the report
+type Entry struct {
+	Page              string   `json:"page"`
+	Sources           []string `json:"sources"`
+	WebURL            string   `json:"weburl,omitempty"`
+	Lastmod           string   `json:"lastmod"`
+	RepositoryLastmod string   `json:"repositoryLastmod,omitempty"`
+	Author            string   `json:"author,omitempty"`
+	Owners            []string `json:"owners,omitempty"`
+	Contributors      int      `json:"contributors"`
+}
+
+// Generate creates the freshness report of the pages with git info. The repository lastmod of a page
+// is read through the registry and is the latest commit in the repositories of its sources.
+func Generate(ctx context.Context, r registry.Interface, infos map[*manifest.Node]*repositoryhost.GitInfo, o Options) (*Report, error) {
+	report := &Report{
+		Generated:           o.Now.Format(repositoryhost.DateFormat),
+		StaleMonths:         o.StaleMonths,
+		RepositoryLagMonths: o.RepositoryLagMonths,
+		Stale:               []*Entry{},
+		BehindRepository:    []*Entry{},
+		SingleContributor:   []*Entry{},
+	}
+	staleBefore := o.Now.AddDate(0, -o.StaleMonths, 0)
+	repositories := map[string]time.Time{}
+	for node, info := range infos {
+		lastmod, err := time.Parse(repositoryhost.DateFormat, info.GetLastModifiedDate())
+		if err != nil {
+			continue
+		}
+		entry := &Entry{
+			Page:         node.NodePath(),
+			Sources:      nodeSources(node),
+			WebURL:       stringValue(info.WebURL),
+			Lastmod:      info.GetLastModifiedDate(),
+			Author:       userName(info.Author),
+			Owners:       info.Owners,
+			Contributors: len(info.Contributors),
+		}
+		if info.Author != nil {
+			entry.Contributors++
+		}
+		repositoryLastmod, err := readRepositoryLastmod(ctx, r, entry.Sources, repositories)
+		if err != nil {
+			return nil, err
+		}
+		if !repositoryLastmod.IsZero() {
+			entry.RepositoryLastmod = repositoryLastmod.Format(repositoryhost.DateFormat)
+		}
+		if lastmod.Before(staleBefore) {
+			report.Stale = append(report.Stale, entry)
+		}
+		if repositoryLastmod.After(lastmod.AddDate(0, o.RepositoryLagMonths, 0)) {
+			report.BehindRepository = append(report.BehindRepository, entry)
+		}
+		if entry.Contributors == 1 {
+			report.SingleContributor = append(report.SingleContributor, entry)
+		}
+	}
+	for _, entries := range [][]*Entry{report.Stale, report.BehindRepository, report.SingleContributor} {
+		slices.SortFunc(entries, func(a, b *Entry) int { return strings.Compare(a.Page, b.Page) })
+	}
+	return report, nil
+}
+
+// readRepositoryLastmod returns the latest lastmod of the repositories of sources, which is zero when
+// the repositories have no commits. The lastmod is cached per repository reference.
+func readRepositoryLastmod(ctx context.Context, r registry.Interface, sources []string, repositories map[string]time.Time) (time.Time, error) {
+	var latest time.Time
+	for _, source := range sources {
+		resourceURL, err := r.ResourceURL(source)
+		if err != nil || resourceURL == nil {
+			continue
+		}
+		refURL := resourceURL.ReferenceURL().String()
+		lastmod, ok := repositories[refURL]
+		if !ok {
+			content, err := r.ReadRepositoryGitInfo(ctx, source)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to read git info of repository %s: %v", refURL, err)
+			}
+			if content == nil {
+				klog.Warningf("no git info for repository %s, its pages are not checked against it", refURL)
+			} else {
+				info := &repositoryhost.GitInfo{}
+				if err = json.Unmarshal(content, info); err != nil {
+					return time.Time{}, fmt.Errorf("failed to parse git info of repository %s: %v", refURL, err)
+				}
+				lastmod, _ = time.Parse(repositoryhost.DateFormat, info.GetLastModifiedDate())
+			}
+			repositories[refURL] = lastmod
+		}
+		if lastmod.After(latest) {
+			latest = lastmod
+		}
+	}
+	return latest, nil
+}
+
+func nodeSources(node *manifest.Node) []string {
+	var sources []string
+	if len(node.Source) > 0 {
+		sources = append(sources, node.Source)
+	}
+	return append(sources, node.MultiSource...)
+}
+
+func userName(user *github.User) string {
+	if user.GetName() != "" {
+		return user.GetName()
+	}
+	return user.GetLogin()
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// JSON returns the report as JSON document
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown returns the report as markdown page with a frontmatter in the given format
+func (r *Report) Markdown(frontmatterFormat string) ([]byte, error) {
+	page := &bytes.Buffer{}
+	frontmatter, err := manifest.MarshalFrontmatter(map[string]interface{}{"title": "Documentation Freshness"}, frontmatterFormat)
+	if err != nil {
+		return nil, err
+	}
+	page.Write(frontmatter)
+	fmt.Fprintf(page, "\nGenerated on %s.\n", r.Generated)
+	fmt.Fprintf(page, "\n## Stale Pages\n\nPages not modified in the last %d months.\n\n", r.StaleMonths)
+	writeTable(page, r.Stale, "Author", func(e *Entry) string { return e.Author })
+	fmt.Fprintf(page, "\n## Pages Behind Their Repository\n\nPages whose source repository is modified more than %d months after them.\n\n", r.RepositoryLagMonths)
+	writeTable(page, r.BehindRepository, "Repository Last Modified", func(e *Entry) string { return e.RepositoryLastmod })
+	fmt.Fprintf(page, "\n## Single Contributor Pages\n\nPages with a single contributor.\n\n")
+	writeTable(page, r.SingleContributor, "Author", func(e *Entry) string { return e.Author })
+	return page.Bytes(), nil
+}
+
+func writeTable(page *bytes.Buffer, entries []*Entry, column string, value func(*Entry) string) {
+	if len(entries) == 0 {
+		fmt.Fprintf(page, "None.\n")
+		return
+	}
+	fmt.Fprintf(page, "| Page | Last Modified | %s | Owners |\n| --- | --- | --- | --- |\n", column)
+	for _, e := range entries {
+		name := "`" + e.Page + "`"
+		if e.WebURL != "" {
+			name = "[" + name + "](" + e.WebURL + ")"
+		}
+		fmt.Fprintf(page, "| %s | %s | %s | %s |\n", name, e.Lastmod, value(e), strings.Join(e.Owners, ", "))
+	}
+}
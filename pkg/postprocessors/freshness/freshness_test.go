@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package freshness_test
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/postprocessors/freshness"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/registryfakes"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFreshness(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Freshness Suite")
+}
+
+//go:embed tests/*
+var repository embed.FS
+
+var _ = Describe("Freshness", func() {
+	var (
+		a, b, c *manifest.Node
+		infos   map[*manifest.Node]*repositoryhost.GitInfo
+		r       *registryfakes.FakeInterface
+	)
+
+	BeforeEach(func() {
+		r = &registryfakes.FakeInterface{}
+		r.ResourceURLCalls(registry.NewRegistry(repositoryhost.NewLocalTest(repository, "https://github.com/gardener/docforge", "tests")).ResourceURL)
+		r.ReadRepositoryGitInfoReturns([]byte(`{"lastmod": "2025-05-01 10:00:00"}`), nil)
+		a = &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "a.md", Source: "https://github.com/gardener/docforge/blob/master/docs/a.md"}}
+		b = &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "b.md", Source: "https://github.com/gardener/docforge/blob/master/docs/b.md"}}
+		c = &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "c.md", Source: "https://github.com/gardener/docforge/blob/master/docs/c.md"}}
+		infos = map[*manifest.Node]*repositoryhost.GitInfo{
+			a: {
+				LastModifiedDate: github.String("2024-01-01 10:00:00"),
+				Author:           &github.User{Name: github.String("one")},
+				WebURL:           github.String("https://github.com/gardener/docforge/blob/master/docs/a.md"),
+				Owners:           []string{"@docs"},
+			},
+			b: {
+				LastModifiedDate: github.String("2025-04-01 10:00:00"),
+				Author:           &github.User{Name: github.String("two")},
+				Contributors:     []*github.User{{Name: github.String("one")}},
+			},
+			c: {
+				LastModifiedDate: github.String("2024-10-01 10:00:00"),
+				Author:           &github.User{Login: github.String("three")},
+				Contributors:     []*github.User{{Name: github.String("one")}},
+			},
+		}
+	})
+
+	It("reports stale pages, pages behind their repository and single contributor pages", func() {
+		report, err := freshness.Generate(context.TODO(), r, infos, freshness.Options{StaleMonths: 12, RepositoryLagMonths: 6, Now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.ReadRepositoryGitInfoCallCount()).To(Equal(1))
+		Expect(report.Generated).To(Equal("2025-06-01 00:00:00"))
+		entryA := &freshness.Entry{
+			Page:              "docs/a.md",
+			Sources:           []string{"https://github.com/gardener/docforge/blob/master/docs/a.md"},
+			WebURL:            "https://github.com/gardener/docforge/blob/master/docs/a.md",
+			Lastmod:           "2024-01-01 10:00:00",
+			RepositoryLastmod: "2025-05-01 10:00:00",
+			Author:            "one",
+			Owners:            []string{"@docs"},
+			Contributors:      1,
+		}
+		entryC := &freshness.Entry{
+			Page:              "docs/c.md",
+			Sources:           []string{"https://github.com/gardener/docforge/blob/master/docs/c.md"},
+			Lastmod:           "2024-10-01 10:00:00",
+			RepositoryLastmod: "2025-05-01 10:00:00",
+			Author:            "three",
+			Contributors:      2,
+		}
+		Expect(report.Stale).To(Equal([]*freshness.Entry{entryA}))
+		Expect(report.BehindRepository).To(Equal([]*freshness.Entry{entryA, entryC}))
+		Expect(report.SingleContributor).To(Equal([]*freshness.Entry{entryA}))
+
+		content, err := report.Markdown("")
+		Expect(err).NotTo(HaveOccurred())
+		page := string(content)
+		Expect(page).To(HavePrefix("---\ntitle: Documentation Freshness\n---\n"))
+		Expect(page).To(ContainSubstring("| [`docs/a.md`](https://github.com/gardener/docforge/blob/master/docs/a.md) | 2024-01-01 10:00:00 | one | @docs |\n"))
+		Expect(page).To(ContainSubstring("| `docs/c.md` | 2024-10-01 10:00:00 | 2025-05-01 10:00:00 |  |\n"))
+	})
+
+	It("reports no repository lastmod when the repositories have no commits", func() {
+		r.ReadRepositoryGitInfoReturns(nil, nil)
+		report, err := freshness.Generate(context.TODO(), r, infos, freshness.Options{StaleMonths: 12, RepositoryLagMonths: 6, Now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.BehindRepository).To(BeEmpty())
+		Expect(report.Stale).To(HaveLen(1))
+		content, err := report.Markdown("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("## Pages Behind Their Repository\n\nPages whose source repository is modified more than 6 months after them.\n\nNone.\n"))
+	})
+
+	It("fails when the git info of a repository can't be read", func() {
+		r.ReadRepositoryGitInfoReturns(nil, errors.New("fake_read_err"))
+		_, err := freshness.Generate(context.TODO(), r, infos, freshness.Options{StaleMonths: 12, RepositoryLagMonths: 6, Now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+		Expect(err).To(MatchError(ContainSubstring("fake_read_err")))
+	})
+
+	It("writes the report as JSON document and markdown page", func() {
+		writer := &writersfakes.FakeWriter{}
+		postProcessor := &freshness.PostProcessor{
+			Registry:            r,
+			Writer:              writer,
+			Path:                "reports/freshness",
+			StaleMonths:         12,
+			RepositoryLagMonths: 6,
+			FrontmatterFormat:   manifest.FrontmatterFormatTOML,
+		}
+		bundle := &postprocessors.Bundle{GitInfos: infos, Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+		Expect(postprocessors.Run(context.TODO(), bundle, postProcessor)).To(Succeed())
+		Expect(writer.WriteCallCount()).To(Equal(2))
+		name, path, content, _, _ := writer.WriteArgsForCall(0)
+		Expect(name).To(Equal("freshness.json"))
+		Expect(path).To(Equal("reports/"))
+		Expect(string(content)).To(ContainSubstring(`"generated": "2025-06-01 00:00:00"`))
+		name, path, content, _, _ = writer.WriteArgsForCall(1)
+		Expect(name).To(Equal("freshness.md"))
+		Expect(path).To(Equal("reports/"))
+		Expect(string(content)).To(HavePrefix("+++\ntitle = 'Documentation Freshness'\n+++\n"))
+	})
+})
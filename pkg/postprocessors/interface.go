@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postprocessors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+)
+
+// Interface defines the methods post-processors need to implement. Post-processors run in the
+// second stage, once all nodes are processed, and produce aggregate artifacts of the whole bundle
+// such as sitemaps, search indexes or backlink data.
+type Interface interface {
+	// Name is the name of the post-processor
+	Name() string
+	// PostProcess processes the bundle
+	PostProcess(ctx context.Context, bundle *Bundle) error
+}
+
+// DocumentRecorder records the metadata of the documents processed in the first stage
+type DocumentRecorder interface {
+	// RecordDocument records the metadata of the document of a node
+	RecordDocument(node *manifest.Node, document *Document)
+	// RecordsText tells whether the plain text of the documents is recorded
+	RecordsText() bool
+}
+
+// Bundle is the documentation bundle produced by the first stage
+type Bundle struct {
+	// Root is the root of the resolved node tree
+	Root *manifest.Node
+	// Nodes are all nodes of the resolved node tree
+	Nodes []*manifest.Node
+	// Outputs are the files written by the first stage, ordered by path
+	Outputs []*Output
+	// GitInfos are the git info of the documents, if it is read
+	GitInfos map[*manifest.Node]*repositoryhost.GitInfo
+	// Time is the time the bundle is built at
+	Time time.Time
+
+	nodeOutputs map[*manifest.Node]*Output
+}
+
+// Output describes a file written by the first stage
+type Output struct {
+	// Path of the file in the bundle
+	Path string
+	// Node of the file, nil for the resources downloaded for the documents
+	Node *manifest.Node
+	// Document describes the markdown documents
+	Document *Document
+}
+
+// Document describes a written markdown document
+type Document struct {
+	// Frontmatter of the document
+	Frontmatter map[string]interface{}
+	// Links are the resolved destinations of the links in the document, in order
+	Links []string
+	// Headings of the document, in order
+	Headings []Heading
+	// Text is the plain text of the document, if the Recorder records it
+	Text string
+}
+
+// Heading is a heading of a document
+type Heading struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+// Output returns the output of a node, which is nil if nothing was written for it
+func (b *Bundle) Output(node *manifest.Node) *Output {
+	if b.nodeOutputs == nil {
+		b.nodeOutputs = make(map[*manifest.Node]*Output, len(b.Outputs))
+		for _, output := range b.Outputs {
+			if output.Node != nil {
+				b.nodeOutputs[output.Node] = output
+			}
+		}
+	}
+	return b.nodeOutputs[node]
+}
+
+// Run runs the post-processors on the bundle in the given order
+func Run(ctx context.Context, bundle *Bundle, processors ...Interface) error {
+	for _, processor := range processors {
+		if err := processor.PostProcess(ctx, bundle); err != nil {
+			return fmt.Errorf("post-processor %s failed: %w", processor.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postprocessors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPostprocessors(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Postprocessors Suite")
+}
+
+type fakePostProcessor struct {
+	name    string
+	err     error
+	bundles []*postprocessors.Bundle
+}
+
+func (f *fakePostProcessor) Name() string {
+	return f.name
+}
+
+func (f *fakePostProcessor) PostProcess(_ context.Context, bundle *postprocessors.Bundle) error {
+	f.bundles = append(f.bundles, bundle)
+	return f.err
+}
+
+var _ = Describe("Postprocessors", func() {
+	Describe("#Recorder", func() {
+		It("records the written files and the documents of their nodes", func() {
+			next := &writersfakes.FakeWriter{}
+			recorder := postprocessors.NewRecorder(next)
+			doc := &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "a.md"}}
+			index := &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "README.md"}}
+			document := &postprocessors.Document{Frontmatter: map[string]interface{}{"title": "A"}, Links: []string{"/docs/"}, Headings: []postprocessors.Heading{{Level: 1, Text: "A", Anchor: "a"}}}
+			recorder.RecordDocument(doc, document)
+			Expect(recorder.Write("a.md", "docs", []byte("# A"), doc, []string{"README.md"})).To(Succeed())
+			Expect(recorder.Write("README.md", "docs", nil, index, []string{"README.md"})).To(Succeed())
+			Expect(recorder.Write("image.png", "__resources", []byte("png"), nil, nil)).To(Succeed())
+			Expect(recorder.Write("empty.md", "docs", nil, nil, nil)).To(Succeed())
+
+			Expect(recorder.Outputs()).To(Equal([]*postprocessors.Output{
+				{Path: "__resources/image.png"},
+				{Path: "docs/_index.md", Node: index},
+				{Path: "docs/a.md", Node: doc, Document: document},
+			}))
+			Expect(next.WriteCallCount()).To(Equal(4))
+			name, _, _, _, _ := next.WriteArgsForCall(1)
+			Expect(name).To(Equal("README.md"))
+		})
+	})
+
+	Describe("#Run", func() {
+		It("runs the post-processors in order until one fails", func() {
+			doc := &manifest.Node{Type: "file", Path: "docs", FileType: manifest.FileType{File: "a.md"}}
+			bundle := &postprocessors.Bundle{Outputs: []*postprocessors.Output{{Path: "docs/a.md", Node: doc}}}
+			first := &fakePostProcessor{name: "first"}
+			second := &fakePostProcessor{name: "second", err: errors.New("index failed")}
+			third := &fakePostProcessor{name: "third"}
+			err := postprocessors.Run(context.TODO(), bundle, first, second, third)
+			Expect(err).To(MatchError("post-processor second failed: index failed"))
+			Expect(first.bundles).To(Equal([]*postprocessors.Bundle{bundle}))
+			Expect(second.bundles).To(HaveLen(1))
+			Expect(third.bundles).To(BeEmpty())
+			Expect(bundle.Output(doc)).To(Equal(bundle.Outputs[0]))
+			Expect(bundle.Output(&manifest.Node{})).To(BeNil())
+		})
+	})
+})
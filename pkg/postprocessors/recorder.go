@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postprocessors
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// Recorder is a writer recording the files written by the first stage. The writes are passed on
+// to the next writer. The document processing records the metadata of the documents before they are written.
+type Recorder struct {
+	// RecordText records the plain text of the documents, which is needed for full-text search only
+	RecordText bool
+
+	next writers.Writer
+
+	mutex     sync.Mutex
+	outputs   map[string]*Output
+	documents map[*manifest.Node]*Document
+}
+
+// NewRecorder creates a Recorder passing the writes on to next
+func NewRecorder(next writers.Writer) *Recorder {
+	return &Recorder{next: next, outputs: map[string]*Output{}, documents: map[*manifest.Node]*Document{}}
+}
+
+// Write records a written file and passes it on to the next writer
+func (r *Recorder) Write(name, filePath string, content []byte, node *manifest.Node, indexFileNames []string) error {
+	outputName := name
+	if slices.Contains(indexFileNames, name) {
+		outputName = "_index.md"
+	}
+	// files without content are skipped by the writers, except for the section files of nodes
+	if len(content) > 0 || (node != nil && outputName == "_index.md") {
+		outputPath := path.Join(filePath, outputName)
+		r.mutex.Lock()
+		r.outputs[outputPath] = &Output{Path: outputPath, Node: node}
+		r.mutex.Unlock()
+	}
+	return r.next.Write(name, filePath, content, node, indexFileNames)
+}
+
+// RecordDocument records the metadata of the document of a node
+func (r *Recorder) RecordDocument(node *manifest.Node, document *Document) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.documents[node] = document
+}
+
+// RecordsText tells whether the plain text of the documents is recorded
+func (r *Recorder) RecordsText() bool {
+	return r.RecordText
+}
+
+// Outputs returns the recorded files ordered by path
+func (r *Recorder) Outputs() []*Output {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	outputs := make([]*Output, 0, len(r.outputs))
+	for _, output := range r.outputs {
+		if output.Node != nil {
+			output.Document = r.documents[output.Node]
+		}
+		outputs = append(outputs, output)
+	}
+	slices.SortFunc(outputs, func(a, b *Output) int { return strings.Compare(a.Path, b.Path) })
+	return outputs
+}
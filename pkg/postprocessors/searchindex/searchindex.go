@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package searchindex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/document/frontmatter"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// PostProcessor writes the search index of the markdown documents of a bundle. The index is a JSON
+// array with an entry per document, which can be loaded by client-side search libraries like Lunr,
+// Fuse or Pagefind. The text of the documents is recorded when they are processed, so that the
+// written files aren't parsed again.
+type PostProcessor struct {
+	Resolver *linkresolver.LinkResolver
+	Writer   writers.Writer
+	// Path of the search index in the bundle
+	Path string
+	// IndexFileNames are the names of the section files
+	IndexFileNames []string
+}
+
+// Entry is the search index entry of a document
+type Entry struct {
+	Title    string                   `json:"title"`
+	URL      string                   `json:"url"`
+	Section  string                   `json:"section,omitempty"`
+	Persona  string                   `json:"persona,omitempty"`
+	Tags     []string                 `json:"tags,omitempty"`
+	Headings []postprocessors.Heading `json:"headings"`
+	// Content is the plain text of the document
+	Content string `json:"content"`
+}
+
+// Name returns the name of the search index post-processor
+func (PostProcessor) Name() string {
+	return "search-index"
+}
+
+// PostProcess writes the search index of the recorded documents
+func (p *PostProcessor) PostProcess(_ context.Context, bundle *postprocessors.Bundle) error {
+	entries, err := p.Entries(bundle)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	dir, name := path.Split(p.Path)
+	return p.Writer.Write(name, dir, content, nil, nil)
+}
+
+// Entries returns the search index entries of the recorded documents, ordered by their path in the bundle
+func (p *PostProcessor) Entries(bundle *postprocessors.Bundle) ([]*Entry, error) {
+	entries := []*Entry{}
+	for _, output := range bundle.Outputs {
+		if output.Node == nil || output.Document == nil {
+			continue
+		}
+		url, err := p.Resolver.WebsiteURL(output.Node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute URL of %s: %w", output.Node.NodePath(), err)
+		}
+		headings := output.Document.Headings
+		if headings == nil {
+			headings = []postprocessors.Heading{}
+		}
+		persona, _ := output.Document.Frontmatter["persona"].(string)
+		entries = append(entries, &Entry{
+			Title:    frontmatter.Title(output.Document.Frontmatter, output.Node, p.IndexFileNames),
+			URL:      url,
+			Section:  p.section(output.Node, bundle),
+			Persona:  persona,
+			Tags:     stringList(output.Document.Frontmatter["tags"]),
+			Headings: headings,
+			Content:  output.Document.Text,
+		})
+	}
+	return entries, nil
+}
+
+// section returns the title of the directory of a document, which is the title of its section file
+// if there is one. It is empty for documents at the root.
+func (p *PostProcessor) section(node *manifest.Node, bundle *postprocessors.Bundle) string {
+	dir := node.Parent()
+	if dir == nil || dir.Type != "dir" || dir.Name() == "" {
+		return ""
+	}
+	for _, child := range dir.Structure {
+		if child.Type != "file" || !p.isIndexFile(child.Name()) {
+			continue
+		}
+		if output := bundle.Output(child); output != nil && output.Document != nil {
+			if t, ok := output.Document.Frontmatter["title"].(string); ok && t != "" {
+				return t
+			}
+		}
+	}
+	return frontmatter.Humanize(dir.Name())
+}
+
+func (p *PostProcessor) isIndexFile(name string) bool {
+	return name == "_index.md" || slices.Contains(p.IndexFileNames, name)
+}
+
+// stringList returns the strings of a frontmatter value, which is either a string or a list
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		list := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package searchindex_test
+
+import (
+	"context"
+	"embed"
+	"testing"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/nodeplugins/markdown/linkresolver"
+	"github.com/gardener/docforge/pkg/postprocessors"
+	"github.com/gardener/docforge/pkg/postprocessors/searchindex"
+	"github.com/gardener/docforge/pkg/registry"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSearchIndex(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Search Index Suite")
+}
+
+//go:embed all:tests/*
+var repo embed.FS
+
+var _ = Describe("Search index", func() {
+	var (
+		processor *searchindex.PostProcessor
+		writer    *writersfakes.FakeWriter
+		bundle    *postprocessors.Bundle
+	)
+
+	BeforeEach(func() {
+		r := registry.NewRegistry(repositoryhost.NewLocalTest(repo, "https://github.com/gardener/docforge", "tests"))
+		nodes, err := manifest.ResolveManifest("https://github.com/gardener/docforge/blob/master/manifest.yaml", r)
+		Expect(err).ToNot(HaveOccurred())
+		writer = &writersfakes.FakeWriter{}
+		processor = &searchindex.PostProcessor{
+			Resolver: linkresolver.New(nodes, r, hugo.Hugo{Enabled: true, BaseURL: "docs"}),
+			Writer:   writer,
+			Path:     "search/index.json",
+		}
+		bundle = &postprocessors.Bundle{Nodes: nodes}
+		for _, node := range nodes {
+			switch node.Name() {
+			case "intro.md":
+				bundle.Outputs = append(bundle.Outputs, &postprocessors.Output{Path: "intro.md", Node: node, Document: &postprocessors.Document{
+					Frontmatter: map[string]interface{}{"title": "Introduction", "persona": "Users", "tags": []interface{}{"overview", "start"}},
+					Headings:    []postprocessors.Heading{{Level: 2, Text: "Concepts", Anchor: "concepts"}},
+					Text:        "Concepts Gardener manages clusters.",
+				}})
+			case "_index.md":
+				bundle.Outputs = append(bundle.Outputs, &postprocessors.Output{Path: "guides/_index.md", Node: node, Document: &postprocessors.Document{
+					Frontmatter: map[string]interface{}{"title": "User Guides"},
+				}})
+			case "install.md":
+				bundle.Outputs = append(bundle.Outputs, &postprocessors.Output{Path: "guides/install.md", Node: node, Document: &postprocessors.Document{
+					Frontmatter: map[string]interface{}{"tags": "setup"},
+					Text:        "Run the installer.",
+				}})
+			}
+		}
+		bundle.Outputs = append(bundle.Outputs, &postprocessors.Output{Path: "guides/image.png"})
+	})
+
+	It("indexes the recorded documents", func() {
+		entries, err := processor.Entries(bundle)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(Equal([]*searchindex.Entry{
+			{
+				Title:    "Introduction",
+				URL:      "/docs/intro/",
+				Persona:  "Users",
+				Tags:     []string{"overview", "start"},
+				Headings: []postprocessors.Heading{{Level: 2, Text: "Concepts", Anchor: "concepts"}},
+				Content:  "Concepts Gardener manages clusters.",
+			},
+			{
+				Title:    "User Guides",
+				URL:      "/docs/guides/",
+				Section:  "User Guides",
+				Headings: []postprocessors.Heading{},
+			},
+			{
+				Title:    "Install",
+				URL:      "/docs/guides/install/",
+				Section:  "User Guides",
+				Tags:     []string{"setup"},
+				Headings: []postprocessors.Heading{},
+				Content:  "Run the installer.",
+			},
+		}))
+	})
+
+	It("writes the search index as JSON array", func() {
+		Expect(postprocessors.Run(context.TODO(), bundle, processor)).To(Succeed())
+		Expect(writer.WriteCallCount()).To(Equal(1))
+		name, path, content, node, _ := writer.WriteArgsForCall(0)
+		Expect(name).To(Equal("index.json"))
+		Expect(path).To(Equal("search/"))
+		Expect(node).To(BeNil())
+		Expect(string(content)).To(HavePrefix(`[{"title":"Introduction","url":"/docs/intro/","persona":"Users","tags":["overview","start"],"headings":[{"level":2,"text":"Concepts","anchor":"concepts"}],"content":"Concepts Gardener manages clusters."},`))
+	})
+})
@@ -6,8 +6,11 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gardener/docforge/pkg/osfakes/httpclient"
@@ -31,6 +34,8 @@ type Interface interface {
 	Read(ctx context.Context, resourceURL string) ([]byte, error)
 	// ReadGitInfo reads the git info for a given resource URL
 	ReadGitInfo(ctx context.Context, resourceURL string) ([]byte, error)
+	// ReadRepositoryGitInfo reads the git info of the repository reference of a given resource URL
+	ReadRepositoryGitInfo(ctx context.Context, resourceURL string) ([]byte, error)
 	// Client returns an HTTP client for accessing the given url
 	Client(url string) httpclient.Client
 	// ResourceURL returns a valid resource url object from a string url
@@ -40,12 +45,23 @@ type Interface interface {
 }
 
 type registry struct {
-	repoHosts []repositoryhost.Interface
+	repoHosts    []repositoryhost.Interface
+	commitFilter *repositoryhost.CommitFilter
+
+	ignoredRevsMutex sync.Mutex
+	// ignoredRevs are the commit SHAs of the ignore revs file per repository reference URL
+	ignoredRevs map[string][]string
 }
 
 // NewRegistry creates Registry object, optionally loading it with resourcerepoHosts if provided
 func NewRegistry(resourcerepoHosts ...repositoryhost.Interface) Interface {
-	return &registry{repoHosts: resourcerepoHosts}
+	return NewRegistryWithCommitFilter(nil, resourcerepoHosts...)
+}
+
+// NewRegistryWithCommitFilter creates Registry object that leaves the commits selected by
+// commitFilter out of the git info, or the Gardener internal commits when commitFilter is nil
+func NewRegistryWithCommitFilter(commitFilter *repositoryhost.CommitFilter, resourcerepoHosts ...repositoryhost.Interface) Interface {
+	return &registry{repoHosts: resourcerepoHosts, commitFilter: commitFilter, ignoredRevs: map[string][]string{}}
 }
 
 func (r *registry) Client(url string) httpclient.Client {
@@ -85,7 +101,53 @@ func (r *registry) ReadGitInfo(ctx context.Context, resourceURL string) ([]byte,
 	if err != nil {
 		return []byte{}, err
 	}
-	return repositoryhost.ReadGitInfo(ctx, rh.Repositories(), *url)
+	ignoredRevs, err := r.readIgnoredRevs(ctx, rh, *url)
+	if err != nil {
+		return nil, err
+	}
+	return repositoryhost.ReadGitInfo(ctx, rh.Repositories(), *url, r.commitFilter, ignoredRevs)
+}
+
+// ReadRepositoryGitInfo reads the git info of the repository reference of a resource URL, so that its lastmod
+// is the date of the latest commit in the repository
+func (r *registry) ReadRepositoryGitInfo(ctx context.Context, resourceURL string) ([]byte, error) {
+	rh, url, err := r.githubRepositoryHost(resourceURL)
+	if err != nil {
+		return []byte{}, err
+	}
+	ignoredRevs, err := r.readIgnoredRevs(ctx, rh, *url)
+	if err != nil {
+		return nil, err
+	}
+	return repositoryhost.ReadGitInfo(ctx, rh.Repositories(), url.ReferenceURL(), r.commitFilter, ignoredRevs)
+}
+
+// readIgnoredRevs reads the ignore revs file of the commit filter from the root of the repository of a resource
+func (r *registry) readIgnoredRevs(ctx context.Context, rh repositoryhost.Interface, resourceURL repositoryhost.URL) ([]string, error) {
+	if r.commitFilter == nil || r.commitFilter.IgnoreRevsFile == "" {
+		return nil, nil
+	}
+	r.ignoredRevsMutex.Lock()
+	defer r.ignoredRevsMutex.Unlock()
+	refURL := resourceURL.ReferenceURL().String()
+	if revs, ok := r.ignoredRevs[refURL]; ok {
+		return revs, nil
+	}
+	fileURL, err := url.JoinPath(resourceURL.RepositoryURLString(), "blob", resourceURL.GetRef(), r.commitFilter.IgnoreRevsFile)
+	if err != nil {
+		return nil, err
+	}
+	file, err := rh.ResourceURL(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	content, err := rh.Read(ctx, *file)
+	var notFound repositoryhost.ErrResourceNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, fmt.Errorf("reading %s failed: %w", fileURL, err)
+	}
+	r.ignoredRevs[refURL] = repositoryhost.ParseIgnoreRevs(content)
+	return r.ignoredRevs[refURL], nil
 }
 
 func (r *registry) LoadRepository(ctx context.Context, resourceURL string) error {
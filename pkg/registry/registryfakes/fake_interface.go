@@ -70,6 +70,20 @@ type FakeInterface struct {
 		result1 []byte
 		result2 error
 	}
+	ReadRepositoryGitInfoStub        func(context.Context, string) ([]byte, error)
+	readRepositoryGitInfoMutex       sync.RWMutex
+	readRepositoryGitInfoArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	readRepositoryGitInfoReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readRepositoryGitInfoReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	ResolveRelativeLinkStub        func(string, string) (string, error)
 	resolveRelativeLinkMutex       sync.RWMutex
 	resolveRelativeLinkArgsForCall []struct {
@@ -399,6 +413,71 @@ func (fake *FakeInterface) ReadGitInfoReturnsOnCall(i int, result1 []byte, resul
 	}{result1, result2}
 }
 
+func (fake *FakeInterface) ReadRepositoryGitInfo(arg1 context.Context, arg2 string) ([]byte, error) {
+	fake.readRepositoryGitInfoMutex.Lock()
+	ret, specificReturn := fake.readRepositoryGitInfoReturnsOnCall[len(fake.readRepositoryGitInfoArgsForCall)]
+	fake.readRepositoryGitInfoArgsForCall = append(fake.readRepositoryGitInfoArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ReadRepositoryGitInfoStub
+	fakeReturns := fake.readRepositoryGitInfoReturns
+	fake.recordInvocation("ReadRepositoryGitInfo", []interface{}{arg1, arg2})
+	fake.readRepositoryGitInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInterface) ReadRepositoryGitInfoCallCount() int {
+	fake.readRepositoryGitInfoMutex.RLock()
+	defer fake.readRepositoryGitInfoMutex.RUnlock()
+	return len(fake.readRepositoryGitInfoArgsForCall)
+}
+
+func (fake *FakeInterface) ReadRepositoryGitInfoCalls(stub func(context.Context, string) ([]byte, error)) {
+	fake.readRepositoryGitInfoMutex.Lock()
+	defer fake.readRepositoryGitInfoMutex.Unlock()
+	fake.ReadRepositoryGitInfoStub = stub
+}
+
+func (fake *FakeInterface) ReadRepositoryGitInfoArgsForCall(i int) (context.Context, string) {
+	fake.readRepositoryGitInfoMutex.RLock()
+	defer fake.readRepositoryGitInfoMutex.RUnlock()
+	argsForCall := fake.readRepositoryGitInfoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeInterface) ReadRepositoryGitInfoReturns(result1 []byte, result2 error) {
+	fake.readRepositoryGitInfoMutex.Lock()
+	defer fake.readRepositoryGitInfoMutex.Unlock()
+	fake.ReadRepositoryGitInfoStub = nil
+	fake.readRepositoryGitInfoReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInterface) ReadRepositoryGitInfoReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readRepositoryGitInfoMutex.Lock()
+	defer fake.readRepositoryGitInfoMutex.Unlock()
+	fake.ReadRepositoryGitInfoStub = nil
+	if fake.readRepositoryGitInfoReturnsOnCall == nil {
+		fake.readRepositoryGitInfoReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readRepositoryGitInfoReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInterface) ResolveRelativeLink(arg1 string, arg2 string) (string, error) {
 	fake.resolveRelativeLinkMutex.Lock()
 	ret, specificReturn := fake.resolveRelativeLinkReturnsOnCall[len(fake.resolveRelativeLinkArgsForCall)]
@@ -605,6 +684,8 @@ func (fake *FakeInterface) Invocations() map[string][][]interface{} {
 	defer fake.readMutex.RUnlock()
 	fake.readGitInfoMutex.RLock()
 	defer fake.readGitInfoMutex.RUnlock()
+	fake.readRepositoryGitInfoMutex.RLock()
+	defer fake.readRepositoryGitInfoMutex.RUnlock()
 	fake.resolveRelativeLinkMutex.RLock()
 	defer fake.resolveRelativeLinkMutex.RUnlock()
 	fake.resourceURLMutex.RLock()
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v43/github"
+)
+
+// CommitFilterOptions configures which commits are internal and left out of the git info
+type CommitFilterOptions struct {
+	// Messages are regular expressions matched against the commit message
+	Messages []string `mapstructure:"messages"`
+	// AuthorEmails are regular expressions matched against the commit author email
+	AuthorEmails []string `mapstructure:"author-emails"`
+	// CommitterEmails are regular expressions matched against the commit committer email
+	CommitterEmails []string `mapstructure:"committer-emails"`
+	// CommitterAccountEmails are regular expressions matched against the email of the GitHub user of the committer only
+	CommitterAccountEmails []string `mapstructure:"committer-account-emails"`
+	// Bots filters the commits of GitHub users of type Bot or with a [bot] login suffix
+	Bots bool `mapstructure:"bots"`
+	// UserTypes are the GitHub user types of the filtered commit authors, e.g. Bot or Organization
+	UserTypes []string `mapstructure:"user-types"`
+	// IgnoreRevsFile is the path of a file in the repository root listing the SHAs of filtered commits,
+	// e.g. .git-blame-ignore-revs
+	IgnoreRevsFile string `mapstructure:"ignore-revs-file"`
+}
+
+// DefaultCommitFilterOptions returns the filter of the Gardener internal commits
+func DefaultCommitFilterOptions() *CommitFilterOptions {
+	return &CommitFilterOptions{
+		Messages:               []string{`^\[int\]`, `\[skip ci\]`},
+		CommitterAccountEmails: []string{`^gardener\.ci`, `^gardener\.opensource`},
+	}
+}
+
+// CommitFilter selects the internal commits that are left out of the git info
+type CommitFilter struct {
+	messages               []*regexp.Regexp
+	authorEmails           []*regexp.Regexp
+	committerEmails        []*regexp.Regexp
+	committerAccountEmails []*regexp.Regexp
+	bots                   bool
+	userTypes              []string
+	// IgnoreRevsFile is the path of the file in the repository root listing the SHAs of filtered commits
+	IgnoreRevsFile string
+}
+
+// NewCommitFilter creates a CommitFilter from options, using the default options when they are nil
+func NewCommitFilter(o *CommitFilterOptions) (*CommitFilter, error) {
+	if o == nil {
+		o = DefaultCommitFilterOptions()
+	}
+	compile := func(kind string, patterns []string) ([]*regexp.Regexp, error) {
+		compiled := make([]*regexp.Regexp, 0, len(patterns))
+		for _, pattern := range patterns {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %s: %w", kind, pattern, err)
+			}
+			compiled = append(compiled, r)
+		}
+		return compiled, nil
+	}
+	f := &CommitFilter{bots: o.Bots, userTypes: o.UserTypes, IgnoreRevsFile: o.IgnoreRevsFile}
+	var err error
+	if f.messages, err = compile("message", o.Messages); err != nil {
+		return nil, err
+	}
+	if f.authorEmails, err = compile("author email", o.AuthorEmails); err != nil {
+		return nil, err
+	}
+	if f.committerEmails, err = compile("committer email", o.CommitterEmails); err != nil {
+		return nil, err
+	}
+	if f.committerAccountEmails, err = compile("committer account email", o.CommitterAccountEmails); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// IsInternal reports if a commit is filtered. ignoredRevs are the SHAs read from the ignore revs file.
+func (f *CommitFilter) IsInternal(commit *github.RepositoryCommit, ignoredRevs []string) bool {
+	matchesAny := func(patterns []*regexp.Regexp, value string) bool {
+		return slices.ContainsFunc(patterns, func(r *regexp.Regexp) bool { return r.MatchString(value) })
+	}
+	if slices.Contains(ignoredRevs, commit.GetSHA()) {
+		return true
+	}
+	if matchesAny(f.messages, commit.GetCommit().GetMessage()) {
+		return true
+	}
+	if matchesAny(f.authorEmails, commit.GetCommit().GetAuthor().GetEmail()) || matchesAny(f.authorEmails, commit.GetAuthor().GetEmail()) {
+		return true
+	}
+	if matchesAny(f.committerEmails, commit.GetCommit().GetCommitter().GetEmail()) || matchesAny(f.committerEmails, commit.GetCommitter().GetEmail()) {
+		return true
+	}
+	if matchesAny(f.committerAccountEmails, commit.GetCommitter().GetEmail()) {
+		return true
+	}
+	author := commit.GetAuthor()
+	if f.bots && (author.GetType() == "Bot" || strings.HasSuffix(author.GetLogin(), "[bot]")) {
+		return true
+	}
+	return author != nil && slices.Contains(f.userTypes, author.GetType())
+}
+
+// ParseIgnoreRevs returns the commit SHAs listed in a .git-blame-ignore-revs file, skipping comments and blank lines
+func ParseIgnoreRevs(content []byte) []string {
+	revs := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			revs = append(revs, line)
+		}
+	}
+	return revs
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost_test
+
+import (
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommitFilter", func() {
+	commit := func(message string, authorEmail string, committerEmail string, author *github.User) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:       github.String("abc"),
+			Author:    author,
+			Committer: &github.User{Email: github.String("one@")},
+			Commit: &github.Commit{
+				Message:   github.String(message),
+				Author:    &github.CommitAuthor{Email: github.String(authorEmail)},
+				Committer: &github.CommitAuthor{Email: github.String(committerEmail)},
+			},
+		}
+	}
+
+	It("filters the Gardener internal commits by default", func() {
+		filter, err := repositoryhost.NewCommitFilter(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.IsInternal(commit("[int] release", "one@", "one@", nil), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("docs [skip ci]", "one@", "one@", nil), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "one@", "one@", nil), nil)).To(BeFalse())
+	})
+
+	It("matches the default emails against the GitHub committer only", func() {
+		filter, err := repositoryhost.NewCommitFilter(nil)
+		Expect(err).NotTo(HaveOccurred())
+		robot := commit("fix", "one@", "one@", nil)
+		robot.Committer = &github.User{Email: github.String("gardener.ci.robot@")}
+		Expect(filter.IsInternal(robot, nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "gardener.ci.robot@", "gardener.opensource@", nil), nil)).To(BeFalse())
+	})
+
+	It("filters the configured commits", func() {
+		filter, err := repositoryhost.NewCommitFilter(&repositoryhost.CommitFilterOptions{
+			Messages:     []string{`^chore\(deps\)`},
+			AuthorEmails: []string{`@bots\.example\.com$`},
+			Bots:         true,
+			UserTypes:    []string{"Organization"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.IsInternal(commit("chore(deps): bump", "one@", "one@", nil), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "release@bots.example.com", "one@", nil), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "one@", "one@", &github.User{Login: github.String("renovate[bot]")}), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "one@", "one@", &github.User{Type: github.String("Bot")}), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "one@", "one@", &github.User{Type: github.String("Organization")}), nil)).To(BeTrue())
+		Expect(filter.IsInternal(commit("fix", "one@", "one@", nil), []string{"abc"})).To(BeTrue())
+		Expect(filter.IsInternal(commit("[int] release", "one@", "gardener.ci@", &github.User{Type: github.String("User")}), nil)).To(BeFalse())
+	})
+
+	It("fails on invalid patterns", func() {
+		_, err := repositoryhost.NewCommitFilter(&repositoryhost.CommitFilterOptions{Messages: []string{"("}})
+		Expect(err).To(MatchError(ContainSubstring("invalid message pattern (")))
+	})
+
+	It("parses ignore revs files", func() {
+		Expect(repositoryhost.ParseIgnoreRevs([]byte("# formatting\nabc\n\n def # gofmt\n"))).To(Equal([]string{"abc", "def"}))
+	})
+})
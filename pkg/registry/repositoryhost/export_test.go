@@ -1,3 +1,10 @@
 package repositoryhost
 
+import "time"
+
 var NewResourceURL = new
+
+// SetGraphQLBatchWindow sets how long GraphQL history requests wait for other requests to join their batch
+func SetGraphQLBatchWindow(repositories Repositories, window time.Duration) {
+	repositories.(*graphQLRepositories).window = window
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v43/github"
+	"k8s.io/klog/v2"
+)
+
+// cachedRepositories persists the commits of files across runs. The commits are keyed by the
+// repository, the ref, the path and the blob SHA of the file, so files that are unchanged at a ref
+// reuse the commits read by a previous run, however many other files changed. The other calls
+// are delegated to repositories.
+type cachedRepositories struct {
+	Repositories
+	dir string
+	// blobSHA returns the blob SHA of a file at a ref or an empty string if the file is unknown
+	blobSHA func(owner, repo, ref, path string) string
+}
+
+// ListCommits returns the cached commits of opts.Path or lists and caches them
+func (c *cachedRepositories) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	sha := c.blobSHA(owner, repo, opts.SHA, opts.Path)
+	if sha == "" {
+		return c.Repositories.ListCommits(ctx, owner, repo, opts)
+	}
+	key := sha256.Sum256([]byte(owner + "/" + repo + "/" + opts.Path + "@" + opts.SHA + ":" + sha))
+	file := filepath.Join(c.dir, owner, repo, hex.EncodeToString(key[:])+".json")
+	if content, err := os.ReadFile(file); err == nil {
+		commits := []*github.RepositoryCommit{}
+		if err = json.Unmarshal(content, &commits); err == nil {
+			klog.V(6).Infof("reusing cached commits of %s/%s/%s\n", owner, repo, opts.Path)
+			return commits, nil, nil
+		}
+		klog.Warningf("ignoring invalid git info cache file %s: %v", file, err)
+	}
+	commits, resp, err := c.Repositories.ListCommits(ctx, owner, repo, opts)
+	if err != nil || (resp != nil && resp.StatusCode >= 400) {
+		return commits, resp, err
+	}
+	if err := writeCachedCommits(file, commits); err != nil {
+		klog.Warningf("caching the commits of %s/%s/%s failed: %v", owner, repo, opts.Path, err)
+	}
+	return commits, resp, nil
+}
+
+// writeCachedCommits writes the fields of the commits used by the git info to file
+func writeCachedCommits(file string, commits []*github.RepositoryCommit) error {
+	compacted := make([]*github.RepositoryCommit, 0, len(commits))
+	for _, commit := range commits {
+		compacted = append(compacted, &github.RepositoryCommit{
+			SHA:       commit.SHA,
+			HTMLURL:   commit.HTMLURL,
+			Author:    commit.Author,
+			Committer: commit.Committer,
+			Commit: &github.Commit{
+				Message:   commit.GetCommit().Message,
+				Author:    commit.GetCommit().Author,
+				Committer: commit.GetCommit().Committer,
+			},
+		})
+	}
+	content, err := json.Marshal(compacted)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	// concurrent runs sharing the cache never read a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("renaming %s failed: %w", tmp.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost/repositoryhostfakes"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Git info cache", func() {
+	var (
+		cacheDir     string
+		repositories *repositoryhostfakes.FakeRepositories
+		blobSHA      string
+		otherBlobSHA string
+	)
+
+	newGHC := func(ref string) repositoryhost.Interface {
+		git := &repositoryhostfakes.FakeGit{}
+		git.GetTreeReturns(&github.Tree{Entries: []*github.TreeEntry{
+			{Path: github.String("README.md"), Type: github.String("blob"), SHA: github.String(blobSHA)},
+			{Path: github.String("OTHER.md"), Type: github.String("blob"), SHA: github.String(otherBlobSHA)},
+		}}, nil, nil)
+		ghc := repositoryhost.NewGHC("github.com", &repositoryhostfakes.FakeRateLimitSource{}, repositories, git, nil, []string{"github.com"}, cacheDir)
+		Expect(ghc.LoadRepository(context.TODO(), "https://github.com/gardener/docforge/blob/"+ref+"/README.md")).To(Succeed())
+		return ghc
+	}
+
+	readGitInfo := func(ghc repositoryhost.Interface, ref string) string {
+		resourceURL, err := ghc.ResourceURL("https://github.com/gardener/docforge/blob/" + ref + "/README.md")
+		Expect(err).NotTo(HaveOccurred())
+		content, err := repositoryhost.ReadGitInfo(context.TODO(), ghc.Repositories(), *resourceURL, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = os.MkdirTemp("", "git-info-cache")
+		Expect(err).NotTo(HaveOccurred())
+		blobSHA = "1"
+		otherBlobSHA = "1"
+		date := time.Date(2024, time.February, 6, 13, 11, 0, 0, time.UTC)
+		repositories = &repositoryhostfakes.FakeRepositories{}
+		repositories.ListCommitsReturns([]*github.RepositoryCommit{{
+			SHA:     github.String("abc"),
+			HTMLURL: github.String("https://github.com/gardener/docforge/commit/abc"),
+			Author:  &github.User{Login: github.String("one"), Type: github.String("User"), Bio: github.String("unused")},
+			Commit: &github.Commit{
+				Message:   github.String("fix"),
+				Author:    &github.CommitAuthor{Name: github.String("one"), Email: github.String("one@"), Date: &date},
+				Committer: &github.CommitAuthor{Name: github.String("one"), Email: github.String("one@"), Date: &date},
+			},
+		}}, nil, nil)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(cacheDir)).To(Succeed())
+	})
+
+	It("reuses the commits of unchanged files across runs", func() {
+		gitInfo := readGitInfo(newGHC("master"), "master")
+		Expect(repositories.ListCommitsCallCount()).To(Equal(1))
+		Expect(readGitInfo(newGHC("master"), "master")).To(Equal(gitInfo))
+		Expect(repositories.ListCommitsCallCount()).To(Equal(1))
+	})
+
+	It("reuses the commits of unchanged files when other files changed", func() {
+		readGitInfo(newGHC("master"), "master")
+		otherBlobSHA = "2"
+		readGitInfo(newGHC("master"), "master")
+		Expect(repositories.ListCommitsCallCount()).To(Equal(1))
+	})
+
+	It("lists the commits of changed files again", func() {
+		readGitInfo(newGHC("master"), "master")
+		blobSHA = "2"
+		readGitInfo(newGHC("master"), "master")
+		Expect(repositories.ListCommitsCallCount()).To(Equal(2))
+	})
+
+	It("lists the commits of the same file at another reference", func() {
+		readGitInfo(newGHC("master"), "master")
+		readGitInfo(newGHC("v1"), "v1")
+		Expect(repositories.ListCommitsCallCount()).To(Equal(2))
+	})
+})
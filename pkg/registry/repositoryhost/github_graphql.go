@@ -0,0 +1,356 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/docforge/pkg/osfakes/httpclient"
+	"github.com/google/go-github/v43/github"
+)
+
+const (
+	// graphQLBatchSize is the maximum number of paths whose history is queried in one request
+	graphQLBatchSize = 50
+	// graphQLBatchWindow is how long the first history request of a batch waits for other requests to join.
+	// Only the requests in flight join, so a batch holds at most as many paths as there are git info workers.
+	graphQLBatchWindow = 20 * time.Millisecond
+	// graphQLPageSize is the number of commits per history page, the maximum allowed by GitHub
+	graphQLPageSize = 100
+)
+
+// graphQLRepositories implements Repositories with the commit history read through the GitHub GraphQL API.
+// Concurrent history requests for the same repository and ref are batched into one query and each history is
+// paginated up to the first commit. Histories are cached by the commit SHA of the ref. A batch is queried until
+// it is done even if the request that started it is cancelled, as the other requests of the batch wait for it.
+type graphQLRepositories struct {
+	Repositories
+	client   httpclient.Client
+	endpoint string
+	window   time.Duration
+
+	mutex sync.Mutex
+	// pending are the batches that accept new paths per repository ref
+	pending map[string]*historyBatch
+	// commitSHAs are the commit SHAs of the queried repository refs
+	commitSHAs map[string]string
+	// histories are the commits of a path per commit SHA and path
+	histories map[string][]*github.RepositoryCommit
+}
+
+type historyBatch struct {
+	owner string
+	repo  string
+	ref   string
+	paths []string
+	done  chan struct{}
+	err   error
+}
+
+// NewGraphQLRepositories creates Repositories that list commits through the GitHub GraphQL endpoint,
+// e.g. https://api.github.com/graphql, and delegate the other calls to repositories
+func NewGraphQLRepositories(repositories Repositories, client httpclient.Client, endpoint string) Repositories {
+	return &graphQLRepositories{
+		Repositories: repositories,
+		client:       client,
+		endpoint:     endpoint,
+		window:       graphQLBatchWindow,
+		pending:      map[string]*historyBatch{},
+		commitSHAs:   map[string]string{},
+		histories:    map[string][]*github.RepositoryCommit{},
+	}
+}
+
+// GraphQLEndpoint returns the GraphQL endpoint of a GitHub instance
+func GraphQLEndpoint(host string) string {
+	if host == "github.com" {
+		return "https://api.github.com/graphql"
+	}
+	return "https://" + host + "/api/graphql"
+}
+
+// ListCommits returns all commits of opts.Path at the opts.SHA ref. The latest commits of whole repositories
+// are listed by the delegate, as paginating their full history is too expensive.
+func (g *graphQLRepositories) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	if opts.Path == "" {
+		return g.Repositories.ListCommits(ctx, owner, repo, opts)
+	}
+	refKey := owner + "/" + repo + "@" + opts.SHA
+	g.mutex.Lock()
+	if commits, ok := g.cached(refKey, opts.Path); ok {
+		g.mutex.Unlock()
+		return commits, nil, nil
+	}
+	batch, leader := g.pending[refKey], false
+	if batch == nil {
+		batch = &historyBatch{owner: owner, repo: repo, ref: opts.SHA, done: make(chan struct{})}
+		g.pending[refKey] = batch
+		leader = true
+	}
+	if !slices.Contains(batch.paths, opts.Path) {
+		batch.paths = append(batch.paths, opts.Path)
+	}
+	if len(batch.paths) >= graphQLBatchSize {
+		delete(g.pending, refKey)
+	}
+	g.mutex.Unlock()
+
+	if leader {
+		go g.run(context.WithoutCancel(ctx), refKey, batch)
+	}
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, nil, batch.err
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	commits, _ := g.cached(refKey, opts.Path)
+	return commits, nil, nil
+}
+
+func (g *graphQLRepositories) cached(refKey string, path string) ([]*github.RepositoryCommit, bool) {
+	sha, ok := g.commitSHAs[refKey]
+	if !ok {
+		return nil, false
+	}
+	commits, ok := g.histories[sha+":"+path]
+	return commits, ok
+}
+
+// run waits for other requests to join the batch and queries the histories of its paths
+func (g *graphQLRepositories) run(ctx context.Context, refKey string, batch *historyBatch) {
+	defer close(batch.done)
+	time.Sleep(g.window)
+	g.mutex.Lock()
+	if g.pending[refKey] == batch {
+		delete(g.pending, refKey)
+	}
+	paths := slices.Clone(batch.paths)
+	g.mutex.Unlock()
+
+	sha, histories, err := g.queryHistories(ctx, batch.owner, batch.repo, batch.ref, paths)
+	if err != nil {
+		batch.err = fmt.Errorf("querying the history of %s/%s at %s failed: %w", batch.owner, batch.repo, batch.ref, err)
+		return
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.commitSHAs[refKey] = sha
+	for path, commits := range histories {
+		g.histories[sha+":"+path] = commits
+	}
+}
+
+// queryHistories returns the commit SHA of ref and the commits of each path, following the history pages
+func (g *graphQLRepositories) queryHistories(ctx context.Context, owner, repo, ref string, paths []string) (string, map[string][]*github.RepositoryCommit, error) {
+	histories := map[string][]*github.RepositoryCommit{}
+	cursors := map[string]string{}
+	remaining := slices.Clone(paths)
+	expression, sha := ref, ""
+	for len(remaining) > 0 {
+		object, err := g.query(ctx, owner, repo, expression, remaining, cursors)
+		if err != nil {
+			return "", nil, err
+		}
+		// further pages are read from the resolved commit, so that all pages belong to the same history
+		sha, expression = object.OID, object.OID
+		next := []string{}
+		for i, path := range remaining {
+			history, ok := object.Histories[fmt.Sprintf("h%d", i)]
+			if !ok {
+				return "", nil, fmt.Errorf("no history returned for %s", path)
+			}
+			for _, node := range history.Nodes {
+				histories[path] = append(histories[path], node.repositoryCommit())
+			}
+			if history.PageInfo.HasNextPage {
+				cursors[path] = history.PageInfo.EndCursor
+				next = append(next, path)
+			}
+		}
+		remaining = next
+	}
+	for _, path := range paths {
+		if histories[path] == nil {
+			histories[path] = []*github.RepositoryCommit{}
+		}
+	}
+	return sha, histories, nil
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data struct {
+		Repository *struct {
+			Object *graphQLCommit `json:"object"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLCommit is the queried commit with the histories of the paths aliased h0, h1, ...
+type graphQLCommit struct {
+	OID       string
+	Histories map[string]*graphQLHistory
+}
+
+func (c *graphQLCommit) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	c.Histories = map[string]*graphQLHistory{}
+	for name, value := range fields {
+		if name == "oid" {
+			if err := json.Unmarshal(value, &c.OID); err != nil {
+				return err
+			}
+			continue
+		}
+		history := &graphQLHistory{}
+		if err := json.Unmarshal(value, history); err != nil {
+			return err
+		}
+		c.Histories[name] = history
+	}
+	return nil
+}
+
+type graphQLHistory struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []*graphQLHistoryCommit `json:"nodes"`
+}
+
+type graphQLHistoryCommit struct {
+	OID       string          `json:"oid"`
+	Message   string          `json:"message"`
+	URL       string          `json:"url"`
+	Author    *graphQLGitUser `json:"author"`
+	Committer *graphQLGitUser `json:"committer"`
+}
+
+type graphQLGitUser struct {
+	Name  string     `json:"name"`
+	Email string     `json:"email"`
+	Date  *time.Time `json:"date"`
+	User  *struct {
+		Login    string `json:"login"`
+		Email    string `json:"email"`
+		Typename string `json:"__typename"`
+	} `json:"user"`
+}
+
+// repositoryCommit converts a GraphQL history commit to the commit returned by the REST API
+func (c *graphQLHistoryCommit) repositoryCommit() *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{
+		SHA:     github.String(c.OID),
+		HTMLURL: github.String(c.URL),
+		Commit:  &github.Commit{Message: github.String(c.Message)},
+	}
+	if c.Author != nil {
+		commit.Commit.Author = c.Author.commitAuthor()
+		commit.Author = c.Author.user()
+	}
+	if c.Committer != nil {
+		commit.Commit.Committer = c.Committer.commitAuthor()
+		commit.Committer = c.Committer.user()
+	}
+	return commit
+}
+
+func (u *graphQLGitUser) commitAuthor() *github.CommitAuthor {
+	return &github.CommitAuthor{Name: github.String(u.Name), Email: github.String(u.Email), Date: u.Date}
+}
+
+// user returns the GitHub user of a git actor. Apps have no user and are returned as bots.
+func (u *graphQLGitUser) user() *github.User {
+	switch {
+	case u.User != nil:
+		user := &github.User{Login: github.String(u.User.Login), Type: github.String(u.User.Typename)}
+		if u.User.Email != "" {
+			user.Email = github.String(u.User.Email)
+		}
+		return user
+	case strings.HasSuffix(u.Name, "[bot]"):
+		return &github.User{Login: github.String(u.Name), Type: github.String("Bot")}
+	default:
+		return nil
+	}
+}
+
+// query reads a page of the histories of paths at the commit of expression
+func (g *graphQLRepositories) query(ctx context.Context, owner, repo, expression string, paths []string, cursors map[string]string) (*graphQLCommit, error) {
+	variables := map[string]interface{}{"owner": owner, "name": repo, "expression": expression}
+	declarations := []string{"$owner: String!", "$name: String!", "$expression: String!"}
+	histories := &strings.Builder{}
+	for i, path := range paths {
+		variables[fmt.Sprintf("p%d", i)] = path
+		declarations = append(declarations, fmt.Sprintf("$p%d: String!", i))
+		after := "null"
+		if cursor, ok := cursors[path]; ok {
+			variables[fmt.Sprintf("c%d", i)] = cursor
+			declarations = append(declarations, fmt.Sprintf("$c%d: String", i))
+			after = fmt.Sprintf("$c%d", i)
+		}
+		fmt.Fprintf(histories, " h%d: history(first: %d, path: $p%d, after: %s) { ...history }", i, graphQLPageSize, i, after)
+	}
+	query := fmt.Sprintf(`query(%s) { repository(owner: $owner, name: $name) { object(expression: $expression) { ... on Commit { oid%s } } } }
+fragment history on CommitHistoryConnection { pageInfo { hasNextPage endCursor } nodes { oid message url author { ...actor } committer { ...actor } } }
+fragment actor on GitActor { name email date user { login email __typename } }`, strings.Join(declarations, ", "), histories.String())
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("GraphQL query fails with HTTP status: %d", resp.StatusCode)
+	}
+	result := &graphQLResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		errs := make([]error, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			errs = append(errs, errors.New(e.Message))
+		}
+		return nil, errors.Join(errs...)
+	}
+	if result.Data.Repository == nil || result.Data.Repository.Object == nil {
+		return nil, fmt.Errorf("commit %s not found", expression)
+	}
+	return result.Data.Repository.Object, nil
+}
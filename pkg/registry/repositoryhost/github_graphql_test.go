@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositoryhost_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gardener/docforge/pkg/osfakes/httpclient/httpclientfakes"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost"
+	"github.com/gardener/docforge/pkg/registry/repositoryhost/repositoryhostfakes"
+	"github.com/google/go-github/v43/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GraphQL repositories", func() {
+	var (
+		client       *httpclientfakes.FakeClient
+		repositories repositoryhost.Repositories
+		requests     []map[string]interface{}
+		responses    []string
+	)
+
+	history := func(hasNextPage bool, cursor string, oid string, date string) string {
+		return `{"pageInfo": {"hasNextPage": ` + map[bool]string{true: "true", false: "false"}[hasNextPage] + `, "endCursor": "` + cursor + `"},
+			"nodes": [{"oid": "` + oid + `", "message": "fix", "url": "https://github.com/gardener/docforge/commit/` + oid + `",
+			"author": {"name": "one", "email": "one@", "date": "` + date + `", "user": {"login": "one", "email": "", "__typename": "User"}},
+			"committer": {"name": "one", "email": "one@", "date": "` + date + `", "user": null}}]}`
+	}
+
+	BeforeEach(func() {
+		requests = nil
+		responses = []string{
+			`{"data": {"repository": {"object": {"oid": "sha1", "h0": ` + history(true, "c1", "a2", "2024-02-07T13:11:00Z") + `, "h1": ` + history(false, "", "b1", "2024-01-01T10:00:00Z") + `}}}}`,
+			`{"data": {"repository": {"object": {"oid": "sha1", "h0": ` + history(false, "", "a1", "2024-02-06T13:11:00Z") + `}}}}`,
+		}
+		mutex := sync.Mutex{}
+		client = &httpclientfakes.FakeClient{}
+		client.DoCalls(func(req *http.Request) (*http.Response, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			body := map[string]interface{}{}
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(req.URL.String()).To(Equal("https://api.github.com/graphql"))
+			requests = append(requests, body["variables"].(map[string]interface{}))
+			response := responses[0]
+			responses = responses[1:]
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(response))}, nil
+		})
+		repositories = repositoryhost.NewGraphQLRepositories(nil, client, repositoryhost.GraphQLEndpoint("github.com"))
+		repositoryhost.SetGraphQLBatchWindow(repositories, 200*time.Millisecond)
+	})
+
+	It("batches the history requests and reads all pages", func() {
+		results := make([][]*github.RepositoryCommit, 2)
+		wg := sync.WaitGroup{}
+		for i, path := range []string{"docs/a.md", "docs/b.md"} {
+			wg.Add(1)
+			go func(i int, path string) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				// the second request joins the batch of the first one
+				time.Sleep(time.Duration(i) * 20 * time.Millisecond)
+				commits, _, err := repositories.ListCommits(context.TODO(), "gardener", "docforge", &github.CommitsListOptions{Path: path, SHA: "master"})
+				Expect(err).NotTo(HaveOccurred())
+				results[i] = commits
+			}(i, path)
+		}
+		wg.Wait()
+		Expect(requests).To(Equal([]map[string]interface{}{
+			{"owner": "gardener", "name": "docforge", "expression": "master", "p0": "docs/a.md", "p1": "docs/b.md"},
+			{"owner": "gardener", "name": "docforge", "expression": "sha1", "p0": "docs/a.md", "c0": "c1"},
+		}))
+		Expect(results[0]).To(HaveLen(2))
+		Expect(results[0][1].GetSHA()).To(Equal("a1"))
+		Expect(results[0][1].GetAuthor().GetLogin()).To(Equal("one"))
+		Expect(results[0][1].GetAuthor().GetType()).To(Equal("User"))
+		Expect(results[0][1].GetCommit().GetCommitter().GetDate()).To(Equal(time.Date(2024, time.February, 6, 13, 11, 0, 0, time.UTC)))
+		Expect(results[0][1].GetCommitter()).To(BeNil())
+		Expect(results[1]).To(HaveLen(1))
+		Expect(results[1][0].GetHTMLURL()).To(Equal("https://github.com/gardener/docforge/commit/b1"))
+
+		By("reusing the cached history")
+		commits, _, err := repositories.ListCommits(context.TODO(), "gardener", "docforge", &github.CommitsListOptions{Path: "docs/b.md", SHA: "master"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commits).To(Equal(results[1]))
+		Expect(client.DoCallCount()).To(Equal(2))
+	})
+
+	It("maps the type and email of the GitHub accounts", func() {
+		responses = []string{`{"data": {"repository": {"object": {"oid": "sha1", "h0": {"pageInfo": {"hasNextPage": false, "endCursor": ""},
+			"nodes": [{"oid": "a1", "message": "fix", "url": "u", "author": {"name": "org", "user": {"login": "org", "email": "org@", "__typename": "Organization"}},
+			"committer": {"name": "renovate[bot]", "user": null}}]}}}}}`}
+		commits, _, err := repositories.ListCommits(context.TODO(), "gardener", "docforge", &github.CommitsListOptions{Path: "docs/a.md", SHA: "master"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commits[0].GetAuthor()).To(Equal(&github.User{Login: github.String("org"), Email: github.String("org@"), Type: github.String("Organization")}))
+		Expect(commits[0].GetCommitter().GetType()).To(Equal("Bot"))
+		filter, err := repositoryhost.NewCommitFilter(&repositoryhost.CommitFilterOptions{UserTypes: []string{"Organization"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.IsInternal(commits[0], nil)).To(BeTrue())
+	})
+
+	It("completes a batch when the request that started it is cancelled", func() {
+		responses = []string{`{"data": {"repository": {"object": {"oid": "sha1", "h0": ` + history(false, "", "a1", "2024-02-06T13:11:00Z") + `, "h1": ` + history(false, "", "b1", "2024-01-01T10:00:00Z") + `}}}}`}
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error)
+		go func() {
+			_, _, err := repositories.ListCommits(ctx, "gardener", "docforge", &github.CommitsListOptions{Path: "docs/a.md", SHA: "master"})
+			leaderErr <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		Expect(<-leaderErr).To(MatchError(context.Canceled))
+		commits, _, err := repositories.ListCommits(context.TODO(), "gardener", "docforge", &github.CommitsListOptions{Path: "docs/b.md", SHA: "master"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commits).To(HaveLen(1))
+		Expect(client.DoCallCount()).To(Equal(1))
+	})
+
+	It("fails on GraphQL errors", func() {
+		responses = []string{`{"errors": [{"message": "Could not resolve to a Repository"}]}`}
+		_, _, err := repositories.ListCommits(context.TODO(), "gardener", "missing", &github.CommitsListOptions{Path: "docs/a.md", SHA: "master"})
+		Expect(err).To(MatchError(ContainSubstring("Could not resolve to a Repository")))
+	})
+
+	It("lists the commits of repositories through the delegate", func() {
+		delegate := &repositoryhostfakes.FakeRepositories{}
+		delegate.ListCommitsReturns([]*github.RepositoryCommit{{SHA: github.String("a1")}}, nil, nil)
+		repositories = repositoryhost.NewGraphQLRepositories(delegate, client, repositoryhost.GraphQLEndpoint("github.com"))
+		commits, _, err := repositories.ListCommits(context.TODO(), "gardener", "docforge", &github.CommitsListOptions{SHA: "master"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commits).To(Equal([]*github.RepositoryCommit{{SHA: github.String("a1")}}))
+		Expect(delegate.ListCommitsCallCount()).To(Equal(1))
+		Expect(client.DoCallCount()).To(Equal(0))
+	})
+})
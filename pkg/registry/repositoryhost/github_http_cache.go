@@ -26,6 +26,8 @@ type ghc struct {
 	rateLimit     RateLimitSource
 	repositories  Repositories
 	acceptedHosts []string
+	// gitInfoCacheDir persists the commits of files across runs when set
+	gitInfoCacheDir string
 
 	repositoryFiles map[string]map[string]string
 }
@@ -53,8 +55,9 @@ type Git interface {
 	GetTree(ctx context.Context, owner string, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error)
 }
 
-// NewGHC creates new GHC resource handler
-func NewGHC(hostName string, rateLimit RateLimitSource, repositories Repositories, git Git, client httpclient.Client, acceptedHosts []string) Interface {
+// NewGHC creates new GHC resource handler. The commits of files are cached in gitInfoCacheDir across runs
+// when it is not empty.
+func NewGHC(hostName string, rateLimit RateLimitSource, repositories Repositories, git Git, client httpclient.Client, acceptedHosts []string, gitInfoCacheDir string) Interface {
 	return &ghc{
 		hostName:        hostName,
 		client:          client,
@@ -62,6 +65,7 @@ func NewGHC(hostName string, rateLimit RateLimitSource, repositories Repositorie
 		rateLimit:       rateLimit,
 		repositories:    repositories,
 		acceptedHosts:   acceptedHosts,
+		gitInfoCacheDir: gitInfoCacheDir,
 		repositoryFiles: map[string]map[string]string{},
 	}
 }
@@ -191,5 +195,17 @@ func (p *ghc) GetRateLimit(ctx context.Context) (int, int, time.Time, error) {
 }
 
 func (p *ghc) Repositories() Repositories {
-	return p.repositories
+	if p.gitInfoCacheDir == "" {
+		return p.repositories
+	}
+	return &cachedRepositories{Repositories: p.repositories, dir: p.gitInfoCacheDir, blobSHA: p.blobSHA}
+}
+
+// blobSHA returns the blob SHA of a file of a loaded repository reference
+func (p *ghc) blobSHA(owner, repo, ref, path string) string {
+	r, err := new(fmt.Sprintf("https://%s/%s/%s/blob/%s/%s", p.hostName, owner, repo, ref, path))
+	if err != nil {
+		return ""
+	}
+	return p.repositoryFiles[r.ReferenceURL().String()][r.ResourceURL()]
 }
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v43/github"
 	"k8s.io/klog/v2"
@@ -27,10 +28,87 @@ type GitInfo struct {
 	SHA              *string        `json:"sha,omitempty"`
 	SHAAlias         *string        `json:"shaalias,omitempty"`
 	Path             *string        `json:"path,omitempty"`
+	// Owners are the code owners of the resource from the CODEOWNERS file of its repository
+	Owners []string `json:"owners,omitempty"`
+	// Source is the URL of the resource in the per-source breakdown of merged git info
+	Source *string `json:"source,omitempty"`
+	// Sources is the per-source breakdown of git info merged from several resources
+	Sources []*GitInfo `json:"sources,omitempty"`
 }
 
-// ReadGitInfo reads the git info for a given resource URL
-func ReadGitInfo(ctx context.Context, repositories Repositories, r URL) ([]byte, error) {
+// MergeGitInfo merges the git info of the sources of a multiSource node. The merged info has the latest
+// lastmod, the earliest publishdate and its author, the union of all other authors and contributors and
+// the per-source breakdown. The weburl, sha, shaalias and path are taken from the first source.
+func MergeGitInfo(sources []string, infos []*GitInfo) *GitInfo {
+	merged := &GitInfo{}
+	var published time.Time
+	for i, info := range infos {
+		if info == nil {
+			continue
+		}
+		if len(merged.Sources) == 0 {
+			merged.WebURL, merged.SHA, merged.SHAAlias, merged.Path = info.WebURL, info.SHA, info.SHAAlias, info.Path
+		}
+		if t, err := time.Parse(DateFormat, info.GetLastModifiedDate()); err == nil && (merged.LastModifiedDate == nil || t.After(lastModified(merged))) {
+			merged.LastModifiedDate = info.LastModifiedDate
+		}
+		if t, err := time.Parse(DateFormat, info.GetPublishDate()); err == nil && (merged.PublishDate == nil || t.Before(published)) {
+			merged.PublishDate, merged.Author, published = info.PublishDate, info.Author, t
+		}
+		source := *info
+		if i < len(sources) {
+			source.Source = github.String(sources[i])
+		}
+		merged.Sources = append(merged.Sources, &source)
+	}
+	if len(merged.Sources) == 0 {
+		return nil
+	}
+	registered := []string{userKey(merged.Author)}
+	for _, info := range merged.Sources {
+		for _, user := range append([]*github.User{info.Author}, info.Contributors...) {
+			if user == nil || slices.Contains(registered, userKey(user)) {
+				continue
+			}
+			merged.Contributors = append(merged.Contributors, user)
+			registered = append(registered, userKey(user))
+		}
+	}
+	return merged
+}
+
+// GetLastModifiedDate returns the LastModifiedDate field if it's non-nil, zero value otherwise
+func (g *GitInfo) GetLastModifiedDate() string {
+	if g == nil || g.LastModifiedDate == nil {
+		return ""
+	}
+	return *g.LastModifiedDate
+}
+
+// GetPublishDate returns the PublishDate field if it's non-nil, zero value otherwise
+func (g *GitInfo) GetPublishDate() string {
+	if g == nil || g.PublishDate == nil {
+		return ""
+	}
+	return *g.PublishDate
+}
+
+func lastModified(info *GitInfo) time.Time {
+	t, _ := time.Parse(DateFormat, info.GetLastModifiedDate())
+	return t
+}
+
+// userKey identifies a user by email, or by login when the email is unknown
+func userKey(user *github.User) string {
+	if user.GetEmail() != "" {
+		return user.GetEmail()
+	}
+	return user.GetLogin()
+}
+
+// ReadGitInfo reads the git info for a given resource URL leaving out the commits selected by filter,
+// or the Gardener internal commits when filter is nil. ignoredRevs are the SHAs read from the ignore revs file.
+func ReadGitInfo(ctx context.Context, repositories Repositories, r URL, filter *CommitFilter, ignoredRevs []string) ([]byte, error) {
 	opts := &github.CommitsListOptions{
 		Path: r.GetResourcePath(),
 		SHA:  r.GetRef(),
@@ -42,7 +120,14 @@ func ReadGitInfo(ctx context.Context, repositories Repositories, r URL) ([]byte,
 	if resp != nil && resp.StatusCode >= 400 {
 		return nil, fmt.Errorf("list commits for %s fails with HTTP status: %d", r.String(), resp.StatusCode)
 	}
-	gitInfo := transform(commits)
+	if filter == nil {
+		if filter, err = NewCommitFilter(nil); err != nil {
+			return nil, err
+		}
+	}
+	gitInfo := transform(commits, func(commit *github.RepositoryCommit) bool {
+		return filter.IsInternal(commit, ignoredRevs)
+	})
 	if gitInfo == nil {
 		return nil, nil
 	}
@@ -58,13 +143,13 @@ func ReadGitInfo(ctx context.Context, repositories Repositories, r URL) ([]byte,
 }
 
 // transform builds git.Info from a commits list
-func transform(commits []*github.RepositoryCommit) *GitInfo {
+func transform(commits []*github.RepositoryCommit, isInternalCommit func(*github.RepositoryCommit) bool) *GitInfo {
 	if commits == nil {
 		return nil
 	}
 	gitInfo := &GitInfo{}
-	// skip internal commits
-	nonInternalCommits := slices.DeleteFunc(commits, isInternalCommit)
+	// skip internal commits, the commits may be cached and are not modified
+	nonInternalCommits := slices.DeleteFunc(slices.Clone(commits), isInternalCommit)
 	if len(nonInternalCommits) == 0 {
 		return nil
 	}
@@ -100,15 +185,6 @@ func transform(commits []*github.RepositoryCommit) *GitInfo {
 	return gitInfo
 }
 
-func isInternalCommit(commit *github.RepositoryCommit) bool {
-	message := commit.GetCommit().GetMessage()
-	email := commit.GetCommitter().GetEmail()
-	return strings.HasPrefix(message, "[int]") ||
-		strings.Contains(message, "[skip ci]") ||
-		strings.HasPrefix(email, "gardener.ci") ||
-		strings.HasPrefix(email, "gardener.opensource")
-}
-
 func getCommitAuthor(commit *github.RepositoryCommit) *github.User {
 	getCommitAuthor := commit.GetCommit().GetAuthor()
 	getCommitCommiter := commit.GetCommit().GetCommitter()
@@ -54,7 +54,7 @@ var _ = Describe("#ReadGitInfo", func() {
 	It("returns correct git info", func() {
 		resourceURl, err := repositoryhost.NewResourceURL("https://github.com/gardener/docforge/blob/master/README.md")
 		Expect(err).NotTo(HaveOccurred())
-		content, err := repositoryhost.ReadGitInfo(context.TODO(), &repositories, *resourceURl)
+		content, err := repositoryhost.ReadGitInfo(context.TODO(), &repositories, *resourceURl, nil, nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(string(content)).To(Equal("{\n  \"lastmod\": \"2024-02-07 13:11:00\",\n  \"publishdate\": \"2024-02-06 13:11:00\",\n  \"author\": {\n    \"name\": \"one\",\n    \"email\": \"one@\"\n  },\n  \"weburl\": \"bar\",\n  \"shaalias\": \"master\",\n  \"path\": \"README.md\"\n}"))
 	})
@@ -56,6 +56,12 @@ type InitOptions struct {
 	EnvCredentials   map[string]string `mapstructure:"github-oauth-env-map"`
 	ResourceMappings map[string]string `mapstructure:"resourceMappings"`
 	Hugo             bool              `mapstructure:"hugo"`
+	// CommitFilter selects the commits left out of the git info, the Gardener internal commits when not set
+	CommitFilter *CommitFilterOptions `mapstructure:"git-info-commit-filter"`
+	// GitInfoGraphQL reads the git info through the GitHub GraphQL API
+	GitInfoGraphQL bool `mapstructure:"github-info-graphql"`
+	// GitInfoCache persists the commits read for the git info in the cache directory across runs
+	GitInfoCache bool `mapstructure:"github-info-cache"`
 }
 
 // Credential holds repository credential data
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/gardener/docforge/pkg/internal/link"
@@ -40,6 +41,32 @@ func RawURL(resourceURL string) (string, error) {
 	return link.Build("https://", r.host, r.owner, r.repo, "raw", r.ref, r.resourcePath)
 }
 
+// RepositoryURL returns the repository url of a resource URL
+func RepositoryURL(resourceURL string) (string, error) {
+	r, err := new(resourceURL)
+	if err != nil {
+		return "", err
+	}
+	return r.RepositoryURLString(), nil
+}
+
+// ReplaceRef replaces the reference of a resource URL with ref if the resource belongs to
+// one of the given repositories. Links that are not resource URLs are returned unchanged.
+func ReplaceRef(resourceURL string, ref string, repositories []string) (string, error) {
+	if !IsResourceURL(resourceURL) {
+		return resourceURL, nil
+	}
+	r, err := new(resourceURL)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(repositories, r.RepositoryURLString()) {
+		return resourceURL, nil
+	}
+	r.ref = ref
+	return r.String(), nil
+}
+
 // URL represents an repsource url
 type URL struct {
 	host           string
@@ -141,3 +141,28 @@ var _ = Describe("URL", func() {
 		})
 	})
 })
+
+var _ = Describe("#ReplaceRef", func() {
+	repositories := []string{"https://github.com/owner/repo"}
+
+	It("replaces the ref of resources in the given repositories", func() {
+		replaced, err := repositoryhost.ReplaceRef("https://github.com/owner/repo/blob/master/docs/README.md#anchor", "v1.80.0", repositories)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replaced).To(Equal("https://github.com/owner/repo/blob/v1.80.0/docs/README.md#anchor"))
+		replaced, err = repositoryhost.ReplaceRef("https://github.com/owner/repo/tree/master/docs", "v1.80.0", repositories)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replaced).To(Equal("https://github.com/owner/repo/tree/v1.80.0/docs"))
+	})
+
+	It("does not change resources from other repositories", func() {
+		replaced, err := repositoryhost.ReplaceRef("https://github.com/owner/other/blob/master/docs/README.md", "v1.80.0", repositories)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replaced).To(Equal("https://github.com/owner/other/blob/master/docs/README.md"))
+	})
+
+	It("does not change relative links", func() {
+		replaced, err := repositoryhost.ReplaceRef("../docs/README.md", "v1.80.0", repositories)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replaced).To(Equal("../docs/README.md"))
+	})
+})
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"github.com/gardener/docforge/pkg/manifest"
+	"k8s.io/klog/v2"
+)
+
+// DryRunWriter is implementation of Writer interface that discards the written blobs
+type DryRunWriter struct{}
+
+func (d *DryRunWriter) Write(name, path string, docBlob []byte, _ *manifest.Node, _ []string) error {
+	klog.V(6).Infof("dry run: skip writing %d bytes to %s/%s\n", len(docBlob), path, name)
+	return nil
+}
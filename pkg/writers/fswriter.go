@@ -5,14 +5,12 @@
 package writers
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 
 	"github.com/gardener/docforge/pkg/manifest"
-	"gopkg.in/yaml.v3"
 )
 
 // FSWriter is implementation of Writer interface for writing blobs to the file system
@@ -20,6 +18,8 @@ type FSWriter struct {
 	Root string
 	Ext  string
 	Hugo bool
+	// FrontmatterFormat of the generated _index.md files, yaml by default
+	FrontmatterFormat string
 }
 
 func (f *FSWriter) Write(name, path string, docBlob []byte, node *manifest.Node, IndexFileNames []string) error {
@@ -28,15 +28,11 @@ func (f *FSWriter) Write(name, path string, docBlob []byte, node *manifest.Node,
 	}
 	//generate _index.md content
 	if f.Hugo && name == "_index.md" && node != nil && node.Frontmatter != nil && docBlob == nil {
-		buf := bytes.Buffer{}
-		_, _ = buf.Write([]byte("---\n"))
-		fm, err := yaml.Marshal(node.Frontmatter)
+		fm, err := manifest.MarshalFrontmatter(node.Frontmatter, f.FrontmatterFormat)
 		if err != nil {
 			return err
 		}
-		_, _ = buf.Write(fm)
-		_, _ = buf.Write([]byte("---\n"))
-		docBlob = buf.Bytes()
+		docBlob = fm
 	}
 	p := filepath.Join(f.Root, path)
 	if len(docBlob) == 0 {